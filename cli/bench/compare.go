@@ -0,0 +1,149 @@
+package bench
+
+import (
+	"math"
+	"slices"
+	"sort"
+)
+
+// Verdicts of a comparison.
+const (
+	Regressed = "regressed"
+	Improved  = "improved"
+	Unchanged = "unchanged"
+	Added     = "added"
+	Removed   = "removed"
+)
+
+// Comparison is one workload entry measured in two runs. Latencies are the
+// medians, in milliseconds; a query only in one run has zero for the other
+// and a p-value of 1.
+type Comparison struct {
+	Query       string   `json:"query"`
+	Args        []string `json:"args"`
+	OldP50      float64  `json:"old_p50_ms"`
+	NewP50      float64  `json:"new_p50_ms"`
+	DeltaMs     float64  `json:"delta_ms"`
+	DeltaPct    float64  `json:"delta_pct"`
+	PValue      float64  `json:"p_value"`
+	Significant bool     `json:"significant"`
+	Verdict     string   `json:"verdict"`
+}
+
+// Entry returns the workload entry the comparison is about.
+func (c Comparison) Entry() Entry {
+	return Entry{Query: c.Query, Args: c.Args}
+}
+
+// Compare pairs the entries of a base run and a later head run by query and
+// arguments, in the order of the head run followed by entries it dropped.
+// A query regressed when its median grew by more than threshold percent
+// and the Mann-Whitney test says the two sets of samples differ at level
+// alpha; improved is the same the other way round.
+func Compare(base, head *Report, threshold, alpha float64) []Comparison {
+	baseline := map[string]Result{}
+	for _, r := range base.Queries {
+		baseline[r.Entry().String()] = r
+	}
+
+	var comparisons []Comparison
+	seen := map[string]bool{}
+	for _, r := range head.Queries {
+		key := r.Entry().String()
+		seen[key] = true
+		o, ok := baseline[key]
+		if !ok {
+			comparisons = append(comparisons, Comparison{Query: r.Query, Args: r.Args, NewP50: r.Stats.P50, PValue: 1, Verdict: Added})
+			continue
+		}
+		c := Comparison{
+			Query:   r.Query,
+			Args:    r.Args,
+			OldP50:  o.Stats.P50,
+			NewP50:  r.Stats.P50,
+			DeltaMs: r.Stats.P50 - o.Stats.P50,
+			Verdict: Unchanged,
+		}
+		if o.Stats.P50 > 0 {
+			c.DeltaPct = c.DeltaMs / o.Stats.P50 * 100
+		}
+		_, c.PValue = MannWhitney(o.Samples, r.Samples)
+		c.Significant = c.PValue < alpha
+		switch {
+		case c.Significant && c.DeltaPct > threshold:
+			c.Verdict = Regressed
+		case c.Significant && c.DeltaPct < -threshold:
+			c.Verdict = Improved
+		}
+		comparisons = append(comparisons, c)
+	}
+	for _, o := range base.Queries {
+		if !seen[o.Entry().String()] {
+			comparisons = append(comparisons, Comparison{Query: o.Query, Args: o.Args, OldP50: o.Stats.P50, PValue: 1, Verdict: Removed})
+		}
+	}
+	return comparisons
+}
+
+// MannWhitney runs the two-sided Mann-Whitney U test on two sets of samples
+// and returns U for a and the p-value. It uses the normal approximation
+// with a correction for ties and for continuity, which is sound from about
+// eight samples a side; with fewer, differences are rarely significant.
+func MannWhitney(a, b []float64) (u, p float64) {
+	n1, n2 := float64(len(a)), float64(len(b))
+	if n1 == 0 || n2 == 0 {
+		return 0, 1
+	}
+
+	type sample struct {
+		value float64
+		fromA bool
+	}
+	all := make([]sample, 0, len(a)+len(b))
+	for _, v := range a {
+		all = append(all, sample{v, true})
+	}
+	for _, v := range b {
+		all = append(all, sample{v, false})
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].value < all[j].value })
+
+	// Tied samples share the mean of the ranks they span.
+	var rankA, ties float64
+	for i := 0; i < len(all); {
+		j := i
+		for j < len(all) && all[j].value == all[i].value {
+			j++
+		}
+		rank := float64(i+j+1) / 2
+		for k := i; k < j; k++ {
+			if all[k].fromA {
+				rankA += rank
+			}
+		}
+		t := float64(j - i)
+		ties += t*t*t - t
+		i = j
+	}
+
+	u = rankA - n1*(n1+1)/2
+	n := n1 + n2
+	mean := n1 * n2 / 2
+	sigma := math.Sqrt(n1 * n2 / 12 * ((n + 1) - ties/(n*(n-1))))
+	if sigma == 0 {
+		return u, 1
+	}
+	diff := math.Abs(u-mean) - 0.5
+	if diff < 0 {
+		diff = 0
+	}
+	z := diff / sigma
+	return u, math.Erfc(z / math.Sqrt2)
+}
+
+// Regressions returns the comparisons that regressed.
+func Regressions(comparisons []Comparison) []Comparison {
+	return slices.DeleteFunc(slices.Clone(comparisons), func(c Comparison) bool {
+		return c.Verdict != Regressed
+	})
+}
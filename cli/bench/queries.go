@@ -0,0 +1,126 @@
+package bench
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/DavidZayar/cli/graph"
+	"github.com/DavidZayar/cli/store"
+)
+
+// query is a read-only dbcli query that can be run over and over. Run
+// returns how many rows the answer has, so runs can be checked for sameness.
+type query struct {
+	args []string
+	run  func(ctx context.Context, st store.GraphStore, args []string) (int, error)
+}
+
+func node(run func(ctx context.Context, st store.GraphStore, node string) (int, error)) query {
+	return query{args: []string{"node"}, run: func(ctx context.Context, st store.GraphStore, args []string) (int, error) {
+		return run(ctx, st, args[0])
+	}}
+}
+
+func scan(run func(ctx context.Context, st store.GraphStore) (int, error)) query {
+	return query{run: func(ctx context.Context, st store.GraphStore, args []string) (int, error) {
+		return run(ctx, st)
+	}}
+}
+
+func distance(run func(ctx context.Context, st store.GraphStore, node string, distance int) ([]graph.PathResult, error)) query {
+	return query{args: []string{"node", "distance"}, run: func(ctx context.Context, st store.GraphStore, args []string) (int, error) {
+		d, _ := strconv.Atoi(args[1])
+		results, err := run(ctx, st, args[0], d)
+		return len(results), err
+	}}
+}
+
+// queries are the commands a workload may name. fourteen renames a node and
+// is left out: running it twice does not do the same work.
+var queries = map[string]query{
+	"one": node(func(ctx context.Context, st store.GraphStore, n string) (int, error) {
+		edges, _, err := graph.SuccessorEdges(ctx, st, n, nil, store.Relations{})
+		return len(edges), err
+	}),
+	"two": node(func(ctx context.Context, st store.GraphStore, n string) (int, error) {
+		count, _, err := graph.CountSuccessors(ctx, st, n, store.Relations{})
+		return count, err
+	}),
+	"three": node(func(ctx context.Context, st store.GraphStore, n string) (int, error) {
+		predecessors, err := graph.Predecessors(ctx, st, n, nil, store.Relations{})
+		return len(predecessors), err
+	}),
+	"four": node(func(ctx context.Context, st store.GraphStore, n string) (int, error) {
+		return graph.CountPredecessors(ctx, st, n, store.Relations{})
+	}),
+	"five": node(func(ctx context.Context, st store.GraphStore, n string) (int, error) {
+		neighbors, _, err := graph.Neighbors(ctx, st, n, store.Relations{})
+		return len(neighbors), err
+	}),
+	"six": node(func(ctx context.Context, st store.GraphStore, n string) (int, error) {
+		neighbors, _, err := graph.Neighbors(ctx, st, n, store.Relations{})
+		return len(neighbors), err
+	}),
+	"seven": node(func(ctx context.Context, st store.GraphStore, n string) (int, error) {
+		grandchildren, _, err := graph.Grandchildren(ctx, st, n, store.Relations{})
+		return len(grandchildren), err
+	}),
+	"eight": node(func(ctx context.Context, st store.GraphStore, n string) (int, error) {
+		grandparents, err := graph.Grandparents(ctx, st, n, store.Relations{})
+		return len(grandparents), err
+	}),
+	"nine":     scan(graph.CountNodes),
+	"ten":      scan(graph.CountWithoutSuccessors),
+	"eleven":   scan(graph.CountWithoutPredecessors),
+	"thirteen": scan(graph.CountSingleNeighbor),
+	"twelve": scan(func(ctx context.Context, st store.GraphStore) (int, error) {
+		_, nodes, err := graph.MostNeighbors(ctx, st)
+		return len(nodes), err
+	}),
+	"fifteen": node(func(ctx context.Context, st store.GraphStore, n string) (int, error) {
+		similar, err := graph.Similar(ctx, st, n)
+		return len(similar), err
+	}),
+	"sixteen": {args: []string{"source", "target"}, run: func(ctx context.Context, st store.GraphStore, args []string) (int, error) {
+		path, _, _, err := graph.ShortestPath(ctx, st, args[0], args[1])
+		return len(path), err
+	}},
+	"seventeen": distance(graph.DistantSynonyms),
+	"eighteen":  distance(graph.DistantAntonyms),
+}
+
+// Queries lists the query names a workload may use.
+func Queries() []string {
+	names := make([]string, 0, len(queries))
+	for name := range queries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func check(e Entry) error {
+	if e.Query == "fourteen" {
+		return fmt.Errorf("fourteen changes the graph and cannot be benchmarked")
+	}
+	q, ok := queries[e.Query]
+	if !ok {
+		return fmt.Errorf("unknown query %q, expected one of %s", e.Query, strings.Join(Queries(), ", "))
+	}
+	if len(e.Args) != len(q.args) {
+		usage := e.Query
+		if len(q.args) > 0 {
+			usage += " <" + strings.Join(q.args, "> <") + ">"
+		}
+		return fmt.Errorf("%s takes %d arguments, expected: %s", e.Query, len(q.args), usage)
+	}
+	if len(q.args) == 2 && q.args[1] == "distance" {
+		if d, err := strconv.Atoi(e.Args[1]); err != nil || d < 1 {
+			return fmt.Errorf("distance must be a positive number, got %q", e.Args[1])
+		}
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package bench
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/DavidZayar/cli/store"
+)
+
+// SchemaVersion is bumped whenever a field of Report or Row changes meaning.
+const SchemaVersion = 1
+
+// Report is a whole benchmark run: where and on what it ran, and the
+// results of every workload entry in workload order. It is the JSON form.
+type Report struct {
+	Schema      int          `json:"schema"`
+	Started     time.Time    `json:"started"`
+	GitRev      string       `json:"git_rev"`
+	Host        string       `json:"host"`
+	GoVersion   string       `json:"go_version"`
+	Platform    string       `json:"platform"`
+	Backend     string       `json:"backend"`
+	Target      string       `json:"target"`
+	Dataset     *store.Stats `json:"dataset"`
+	Workload    string       `json:"workload"`
+	Warmups     int          `json:"warmups"`
+	Reps        int          `json:"reps"`
+	Concurrency int          `json:"concurrency"`
+	Queries     []Result     `json:"queries"`
+}
+
+// NewReport describes a run starting now on this machine. dataset may be
+// nil when the graph was not counted.
+func NewReport(backend, target string, dataset *store.Stats, workload string, opts Options) *Report {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return &Report{
+		Schema:      SchemaVersion,
+		Started:     time.Now().UTC().Truncate(time.Second),
+		GitRev:      Revision(),
+		Host:        host,
+		GoVersion:   runtime.Version(),
+		Platform:    runtime.GOOS + "/" + runtime.GOARCH,
+		Backend:     backend,
+		Target:      target,
+		Dataset:     dataset,
+		Workload:    workload,
+		Warmups:     opts.Warmups,
+		Reps:        opts.Reps,
+		Concurrency: opts.Concurrency,
+	}
+}
+
+// ReadReport reads a report written as JSON.
+func ReadReport(path string) (*Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var r Report
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if r.Schema != SchemaVersion {
+		return nil, fmt.Errorf("%s: benchmark schema %d, expected %d", path, r.Schema, SchemaVersion)
+	}
+	return &r, nil
+}
+
+// Row is one result flattened with the run it belongs to, for CSV and TSV.
+// Each row stands alone, so files from several runs can be concatenated.
+type Row struct {
+	Started      string   `json:"started"`
+	GitRev       string   `json:"git_rev"`
+	Host         string   `json:"host"`
+	Backend      string   `json:"backend"`
+	DatasetNodes string   `json:"dataset_nodes"`
+	DatasetEdges string   `json:"dataset_edges"`
+	Warmups      int      `json:"warmups"`
+	Reps         int      `json:"reps"`
+	Concurrency  int      `json:"concurrency"`
+	Query        string   `json:"query"`
+	Args         []string `json:"args"`
+	Rows         int      `json:"rows"`
+	N            int      `json:"n"`
+	Min          string   `json:"min_ms"`
+	Mean         string   `json:"mean_ms"`
+	Stddev       string   `json:"stddev_ms"`
+	P50          string   `json:"p50_ms"`
+	P95          string   `json:"p95_ms"`
+	P99          string   `json:"p99_ms"`
+	Max          string   `json:"max_ms"`
+	OpsPerSec    string   `json:"ops_per_sec"`
+}
+
+// Rows flattens the report, one row per result. An uncounted dataset
+// leaves its columns empty.
+func (r *Report) Rows() []Row {
+	var nodes, edges string
+	if r.Dataset != nil {
+		nodes = fmt.Sprint(r.Dataset.Nodes)
+		edges = fmt.Sprint(r.Dataset.Edges)
+	}
+	rows := make([]Row, 0, len(r.Queries))
+	for _, q := range r.Queries {
+		rows = append(rows, Row{
+			Started:      r.Started.Format(time.RFC3339),
+			GitRev:       r.GitRev,
+			Host:         r.Host,
+			Backend:      r.Backend,
+			DatasetNodes: nodes,
+			DatasetEdges: edges,
+			Warmups:      r.Warmups,
+			Reps:         r.Reps,
+			Concurrency:  r.Concurrency,
+			Query:        q.Query,
+			Args:         q.Args,
+			Rows:         q.Rows,
+			N:            q.Stats.N,
+			Min:          decimal(q.Stats.Min),
+			Mean:         decimal(q.Stats.Mean),
+			Stddev:       decimal(q.Stats.Stddev),
+			P50:          decimal(q.Stats.P50),
+			P95:          decimal(q.Stats.P95),
+			P99:          decimal(q.Stats.P99),
+			Max:          decimal(q.Stats.Max),
+			OpsPerSec:    decimal(q.OpsPerSec),
+		})
+	}
+	return rows
+}
+
+func decimal(v float64) string {
+	return fmt.Sprintf("%.3f", v)
+}
+
+// Revision is the commit the binary was built from, with -dirty when the
+// tree had changes. Binaries built without VCS stamping, such as with go
+// run or from a single file, ask git instead.
+func Revision() string {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		var rev string
+		dirty := false
+		for _, s := range info.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				rev = s.Value
+			case "vcs.modified":
+				dirty = s.Value == "true"
+			}
+		}
+		if rev != "" {
+			if len(rev) > 12 {
+				rev = rev[:12]
+			}
+			if dirty {
+				rev += "-dirty"
+			}
+			return rev
+		}
+	}
+	out, err := exec.Command("git", "rev-parse", "--short=12", "HEAD").Output()
+	if err != nil {
+		return "unknown"
+	}
+	rev := strings.TrimSpace(string(out))
+	if status, err := exec.Command("git", "status", "--porcelain", "--untracked-files=no").Output(); err == nil && len(status) > 0 {
+		rev += "-dirty"
+	}
+	return rev
+}
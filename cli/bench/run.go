@@ -0,0 +1,139 @@
+package bench
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/DavidZayar/cli/store"
+)
+
+// Options control how often each entry of a workload runs.
+type Options struct {
+	// Warmups are runs made before measuring, to fill caches and pools.
+	Warmups int
+	// Reps are the measured runs of each entry.
+	Reps int
+	// Concurrency is how many of the measured runs are in flight at once.
+	Concurrency int
+}
+
+// Result is the measurement of one workload entry.
+type Result struct {
+	Query   string    `json:"query"`
+	Args    []string  `json:"args"`
+	Rows    int       `json:"rows"`
+	Samples []float64 `json:"samples_ms"`
+	Stats   Stats     `json:"stats"`
+	// OpsPerSec is the measured runs divided by the time they took together,
+	// which is what concurrency buys.
+	OpsPerSec float64 `json:"ops_per_sec"`
+}
+
+// Entry returns the workload entry the result measures.
+func (r Result) Entry() Entry {
+	return Entry{Query: r.Query, Args: r.Args}
+}
+
+// Run measures every entry in turn: warmups one at a time, then the
+// measured runs spread over opts.Concurrency workers. done is called after
+// each entry, so progress can be shown. The first failed run stops the
+// benchmark.
+func Run(ctx context.Context, st store.GraphStore, workload []Entry, opts Options, done func(Result)) ([]Result, error) {
+	if opts.Reps < 1 {
+		return nil, fmt.Errorf("need at least one repetition, got %d", opts.Reps)
+	}
+	if opts.Concurrency < 1 {
+		opts.Concurrency = 1
+	}
+
+	results := make([]Result, 0, len(workload))
+	for _, e := range workload {
+		r, err := runEntry(ctx, st, e, opts)
+		if err != nil {
+			return results, fmt.Errorf("%s: %w", e, err)
+		}
+		results = append(results, r)
+		if done != nil {
+			done(r)
+		}
+	}
+	return results, nil
+}
+
+func runEntry(ctx context.Context, st store.GraphStore, e Entry, opts Options) (Result, error) {
+	q := queries[e.Query]
+	for i := 0; i < opts.Warmups; i++ {
+		if _, err := q.run(ctx, st, e.Args); err != nil {
+			return Result{}, err
+		}
+	}
+
+	samples := make([]float64, opts.Reps)
+	rows := make([]int, opts.Reps)
+	reps := make(chan int)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	start := time.Now()
+	for w := 0; w < min(opts.Concurrency, opts.Reps); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range reps {
+				began := time.Now()
+				n, err := q.run(ctx, st, e.Args)
+				samples[i] = ms(time.Since(began))
+				rows[i] = n
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+feed:
+	for i := 0; i < opts.Reps; i++ {
+		select {
+		case reps <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(reps)
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	if firstErr != nil {
+		return Result{}, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return Result{}, err
+	}
+	for i, n := range rows {
+		if n != rows[0] {
+			return Result{}, fmt.Errorf("run %d returned %d rows, run 1 returned %d", i+1, n, rows[0])
+		}
+	}
+
+	return Result{
+		Query:     e.Query,
+		Args:      e.Args,
+		Rows:      rows[0],
+		Samples:   samples,
+		Stats:     Summarise(samples),
+		OpsPerSec: float64(opts.Reps) / elapsed.Seconds(),
+	}, nil
+}
+
+func ms(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
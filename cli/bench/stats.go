@@ -0,0 +1,61 @@
+package bench
+
+import (
+	"math"
+	"slices"
+)
+
+// Stats summarises the latencies of one query, in milliseconds.
+// Percentiles interpolate between the two nearest samples.
+type Stats struct {
+	N      int     `json:"n"`
+	Min    float64 `json:"min_ms"`
+	Mean   float64 `json:"mean_ms"`
+	Stddev float64 `json:"stddev_ms"`
+	P50    float64 `json:"p50_ms"`
+	P95    float64 `json:"p95_ms"`
+	P99    float64 `json:"p99_ms"`
+	Max    float64 `json:"max_ms"`
+}
+
+// Summarise computes the stats of samples.
+func Summarise(samples []float64) Stats {
+	if len(samples) == 0 {
+		return Stats{}
+	}
+	sorted := slices.Clone(samples)
+	slices.Sort(sorted)
+
+	var sum float64
+	for _, s := range sorted {
+		sum += s
+	}
+	mean := sum / float64(len(sorted))
+	var squares float64
+	for _, s := range sorted {
+		squares += (s - mean) * (s - mean)
+	}
+	stddev := 0.0
+	if len(sorted) > 1 {
+		stddev = math.Sqrt(squares / float64(len(sorted)-1))
+	}
+
+	return Stats{
+		N:      len(sorted),
+		Min:    sorted[0],
+		Mean:   mean,
+		Stddev: stddev,
+		P50:    percentile(sorted, 50),
+		P95:    percentile(sorted, 95),
+		P99:    percentile(sorted, 99),
+		Max:    sorted[len(sorted)-1],
+	}
+}
+
+// percentile returns the pth percentile of sorted samples.
+func percentile(sorted []float64, p float64) float64 {
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
@@ -0,0 +1,111 @@
+// Package bench runs a workload of queries against a graph store many times
+// and summarises the latencies, so runs can be recorded and compared.
+package bench
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Entry is one line of a workload: a query and its arguments.
+type Entry struct {
+	Query string
+	Args  []string
+	Line  int
+}
+
+// String is the entry as it would be written in a workload file.
+func (e Entry) String() string {
+	parts := []string{e.Query}
+	for _, arg := range e.Args {
+		if arg == "" || strings.ContainsFunc(arg, func(r rune) bool {
+			return unicode.IsSpace(r) || r == '"' || r == '#' || r == '`' || !unicode.IsPrint(r)
+		}) {
+			arg = strconv.Quote(arg)
+		}
+		parts = append(parts, arg)
+	}
+	return strings.Join(parts, " ")
+}
+
+// ReadWorkload reads a workload file. Each line names a query and gives its
+// arguments in the order the command takes them, flags dropped:
+//
+//	# query   arguments
+//	one       /c/en/steam_locomotive
+//	sixteen   /c/en/uchuva /c/en/square_sails/n
+//	seventeen /c/en/defeatable 2
+//	nine
+//
+// Arguments are separated by spaces; one holding spaces, quotes or # is
+// written as a Go string literal. Blank lines and lines starting with # are
+// skipped. Every entry is checked against the known queries.
+func ReadWorkload(path string) ([]Entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		fields, err := splitLine(scanner.Text())
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		e := Entry{Query: fields[0], Args: fields[1:], Line: line}
+		if err := check(e); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("%s: no queries in workload", path)
+	}
+	return entries, nil
+}
+
+// splitLine splits a workload line into fields, unquoting Go string
+// literals and stopping at a # outside of one.
+func splitLine(s string) ([]string, error) {
+	var fields []string
+	for {
+		s = strings.TrimLeftFunc(s, unicode.IsSpace)
+		if s == "" || s[0] == '#' {
+			return fields, nil
+		}
+		if s[0] == '"' || s[0] == '`' {
+			quoted, err := strconv.QuotedPrefix(s)
+			if err != nil {
+				return nil, fmt.Errorf("bad quoted argument %s", s)
+			}
+			field, _ := strconv.Unquote(quoted)
+			fields = append(fields, field)
+			s = s[len(quoted):]
+			if s != "" && !unicode.IsSpace(rune(s[0])) {
+				return nil, fmt.Errorf("missing space after %s", quoted)
+			}
+			continue
+		}
+		end := strings.IndexFunc(s, unicode.IsSpace)
+		if end < 0 {
+			end = len(s)
+		}
+		fields = append(fields, s[:end])
+		s = s[end:]
+	}
+}
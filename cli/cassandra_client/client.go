@@ -1,48 +1,90 @@
 package cassandra_client
 
 import (
-	"os"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
 
-	"github.com/fatih/color"
+	"github.com/DavidZaya21/parser/config"
+	"github.com/DavidZayar/cli/metrics"
 	"github.com/gocql/gocql"
-	"github.com/joho/godotenv"
 )
 
-var session *gocql.Session
+// Client owns one session for the life of the process. Every caller shares
+// it, so several queries in one process reuse the same connection pool;
+// callers must not close the session themselves.
+type Client struct {
+	cfg config.Config
 
-func ConnectionToCassandra() {
-	var err error
+	mu      sync.Mutex
+	session *gocql.Session
+	closed  bool
+}
+
+// New returns a client for cfg. It does not connect until Session is first
+// called.
+func New(cfg config.Config) *Client {
+	return &Client{cfg: cfg}
+}
 
-	if err = godotenv.Load(".env"); err != nil {
-		color.Red("Failed to load .env file: %s", err.Error())
-		return
+// Config returns the settings the client was created with.
+func (c *Client) Config() config.Config {
+	return c.cfg
+}
+
+// Session returns the shared session bound to the configured keyspace,
+// connecting on first use. A failed connection is not cached, so a later
+// call tries again.
+func (c *Client) Session() (*gocql.Session, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil, errors.New("cassandra client is closed")
 	}
-	color.Green("✅ .env file loaded")
-
-	// Get HOST and KEYSPACE
-	host := os.Getenv("HOST")
-	keyspace := os.Getenv("KEYSPACE")
-	if host == "" || keyspace == "" {
-		color.Red("HOST or KEYSPACE not defined in .env file")
-		return
+	if c.session != nil {
+		return c.session, nil
 	}
-	color.Cyan("Connecting to Cassandra at %s, keyspace: %s", host, keyspace)
-
-	// Create Cassandra cluster
-	cluster := gocql.NewCluster(host)
-	cluster.Keyspace = keyspace
-	cluster.Consistency = gocql.One
-	session, err = cluster.CreateSession()
+	cluster, err := c.cfg.Cluster()
+	if err != nil {
+		return nil, err
+	}
+	cluster.Keyspace = c.cfg.Keyspace
+	cluster.QueryObserver = metrics.Observer{}
+	cluster.BatchObserver = metrics.Observer{}
+	session, err := cluster.CreateSession()
 	if err != nil {
-		color.Red("❌ Failed to create Cassandra session: %s", err.Error())
-		return
+		return nil, fmt.Errorf("failed to create Cassandra session for %s: %w", c.cfg.Target(), err)
 	}
-	color.Green("✅ Connected to Cassandra")
+	c.session = session
+	return session, nil
 }
 
-func GetSession() *gocql.Session {
-	return session
+// AdminSession opens a separate session that is not bound to a keyspace, for
+// commands such as schema init that have to work before the keyspace exists.
+// Schema changes run at QUORUM with a longer timeout. The caller closes it.
+func (c *Client) AdminSession() (*gocql.Session, error) {
+	cluster, err := c.cfg.Cluster()
+	if err != nil {
+		return nil, err
+	}
+	cluster.Consistency = gocql.Quorum
+	cluster.Timeout = 30 * time.Second
+	admin, err := cluster.CreateSession()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Cassandra session: %w", err)
+	}
+	return admin, nil
 }
-func Close() {
-	session.Close()
+
+// Close closes the shared session if one was opened. It is safe to call more
+// than once.
+func (c *Client) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.session != nil {
+		c.session.Close()
+		c.session = nil
+	}
+	c.closed = true
 }
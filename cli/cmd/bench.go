@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/DavidZayar/cli/bench"
+	"github.com/DavidZayar/cli/store"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	BenchWarmups      int
+	BenchReps         int
+	BenchConcurrency  int
+	BenchCountDataset bool
+	BenchThreshold    float64
+	BenchAlpha        float64
+
+	BenchCmd = &cobra.Command{
+		Use:   "bench <workload>",
+		Short: "Run a workload of queries repeatedly and report latency percentiles",
+		Long: `Run every query of a workload file with warmups and repeated, optionally
+concurrent, measured runs, and report min/mean/p50/p95/p99/max per query.
+
+Each line of the workload names a query and its arguments, flags dropped:
+
+  one       /c/en/steam_locomotive
+  sixteen   /c/en/uchuva /c/en/square_sails/n
+  seventeen /c/en/defeatable 2
+
+With --output=json the whole run is written as one report, with the git
+revision, host and dataset size; csv, tsv and ndjson write one row per query.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			BenchAction(cmd.Context(), args[0])
+		},
+	}
+
+	BenchCompareCmd = &cobra.Command{
+		Use:   "compare <old.json> <new.json>",
+		Short: "Compare two benchmark runs and fail on significant regressions",
+		Long: `Compare the median latency of every query in two reports written by
+"dbcli bench --output=json". A query regressed when its median grew by more
+than --threshold percent and a Mann-Whitney U test on the samples is
+significant at --alpha. The command exits with status 1 if any query
+regressed.`,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			BenchCompareAction(args[0], args[1])
+		},
+	}
+)
+
+func BenchAction(ctx context.Context, workloadFile string) {
+	workload, err := bench.ReadWorkload(workloadFile)
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	opts := bench.Options{Warmups: BenchWarmups, Reps: BenchReps, Concurrency: BenchConcurrency}
+	if opts.Reps < 1 || opts.Warmups < 0 || opts.Concurrency < 1 {
+		log.Fatal("❌ --reps and --concurrency must be at least 1, --warmups at least 0")
+	}
+
+	st := openStore(ctx)
+	defer st.Close()
+
+	var dataset *store.Stats
+	if BenchCountDataset {
+		color.Cyan("🔢 Counting the dataset...")
+		stats, err := st.Stats(ctx)
+		if err != nil {
+			log.Fatalf("❌ Failed to count the dataset: %v", err)
+		}
+		dataset = &stats
+	}
+
+	report := bench.NewReport(Config.Backend, backendTarget(), dataset, workloadFile, opts)
+	color.Cyan("🏁 %d queries, %d warmups and %d runs each, concurrency %d, revision %s",
+		len(workload), opts.Warmups, opts.Reps, opts.Concurrency, report.GitRev)
+	report.Queries, err = bench.Run(ctx, st, workload, opts, func(r bench.Result) {
+		color.Green("✅ %s: p50 %.3f ms, p99 %.3f ms", r.Entry(), r.Stats.P50, r.Stats.P99)
+	})
+	if err != nil {
+		log.Fatalf("❌ Benchmark failed: %v", err)
+	}
+
+	out := newResults()
+	switch {
+	case out.Table():
+		show(color.FgWhite, "%-40s %6s %10s %10s %10s %10s %10s %10s %8s",
+			"query", "rows", "min", "mean", "p50", "p95", "p99", "max", "ops/s")
+		for _, r := range report.Queries {
+			s := r.Stats
+			show(color.FgYellow, "%-40s %6d %10.3f %10.3f %10.3f %10.3f %10.3f %10.3f %8.1f",
+				truncate(r.Entry().String(), 40), r.Rows, s.Min, s.Mean, s.P50, s.P95, s.P99, s.Max, r.OpsPerSec)
+		}
+		show(color.FgWhite, "Times in ms.")
+	case OutputFormat == OutputJSON:
+		out.Object(report)
+	default:
+		for _, row := range report.Rows() {
+			out.Add(row)
+		}
+	}
+	out.Flush()
+}
+
+func BenchCompareAction(oldFile, newFile string) {
+	base, err := bench.ReadReport(oldFile)
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	head, err := bench.ReadReport(newFile)
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	warnIncomparable(base, head)
+
+	comparisons := bench.Compare(base, head, BenchThreshold, BenchAlpha)
+	out := newResults()
+	if out.Table() {
+		show(color.FgWhite, "%s (%s) -> %s (%s)", base.GitRev, oldFile, head.GitRev, newFile)
+		show(color.FgWhite, "%-40s %10s %10s %9s %8s  %s", "query", "old p50", "new p50", "delta", "p", "verdict")
+	}
+	for _, c := range comparisons {
+		if out.Table() {
+			show(verdictColor[c.Verdict], "%-40s %10.3f %10.3f %+8.1f%% %8.4f  %s",
+				truncate(c.Entry().String(), 40), c.OldP50, c.NewP50, c.DeltaPct, c.PValue, c.Verdict)
+		}
+		out.Add(c)
+	}
+	out.Flush()
+
+	if regressions := bench.Regressions(comparisons); len(regressions) > 0 {
+		color.Red("❌ %d of %d queries regressed by more than %.1f%% (p < %g)",
+			len(regressions), len(comparisons), BenchThreshold, BenchAlpha)
+		os.Exit(1)
+	}
+	color.Green("✅ No query regressed by more than %.1f%%", BenchThreshold)
+}
+
+var verdictColor = map[string]color.Attribute{
+	bench.Regressed: color.FgRed,
+	bench.Improved:  color.FgGreen,
+	bench.Unchanged: color.FgWhite,
+	bench.Added:     color.FgCyan,
+	bench.Removed:   color.FgYellow,
+}
+
+// warnIncomparable points out differences between two runs that move
+// latencies by themselves.
+func warnIncomparable(base, head *bench.Report) {
+	if base.Backend != head.Backend || base.Target != head.Target {
+		color.Yellow("⚠️ Runs read different data: %s %s and %s %s", base.Backend, base.Target, head.Backend, head.Target)
+	}
+	if base.Dataset != nil && head.Dataset != nil && *base.Dataset != *head.Dataset {
+		color.Yellow("⚠️ Dataset changed from %d nodes, %d edges to %d nodes, %d edges",
+			base.Dataset.Nodes, base.Dataset.Edges, head.Dataset.Nodes, head.Dataset.Edges)
+	}
+	if base.Host != head.Host {
+		color.Yellow("⚠️ Runs were made on different hosts: %s and %s", base.Host, head.Host)
+	}
+	if base.Concurrency != head.Concurrency {
+		color.Yellow("⚠️ Runs used different concurrency: %d and %d", base.Concurrency, head.Concurrency)
+	}
+}
+
+func truncate(s string, n int) string {
+	if len([]rune(s)) <= n {
+		return s
+	}
+	return string([]rune(s)[:n-1]) + "…"
+}
+
+func init() {
+	BenchCmd.Flags().IntVar(&BenchWarmups, "warmups", 1, "Unmeasured runs of each query before measuring")
+	BenchCmd.Flags().IntVarP(&BenchReps, "reps", "n", 10, "Measured runs of each query")
+	BenchCmd.Flags().IntVarP(&BenchConcurrency, "concurrency", "c", 1, "Measured runs in flight at once")
+	BenchCmd.Flags().BoolVar(&BenchCountDataset, "count-dataset", true, "Count nodes and edges for the report (a full scan on Cassandra)")
+	BenchCompareCmd.Flags().Float64Var(&BenchThreshold, "threshold", 10, "Percent slower a median may get before it counts as a regression")
+	BenchCompareCmd.Flags().Float64Var(&BenchAlpha, "alpha", 0.05, "Significance level of the Mann-Whitney U test")
+	BenchCmd.AddCommand(BenchCompareCmd)
+}
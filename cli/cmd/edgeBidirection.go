@@ -1,70 +1,195 @@
 package cmd
 
-// import (
-// 	"fmt"
-// 	"log"
-// 	"github.com/DavidZayar/cli/cassandra_client"
-// 	"github.com/gocql/gocql"
-// 	"github.com/google/uuid"  // Add this import
-// 	"github.com/spf13/cobra"
-// )
-
-// // var EdgeBidirectionCmd = &cobra.Command{
-// // 	Use:   "edgeBidirection",
-// // 	Short: "Insert the edges_bidirectional table with undirected edges",
-// // 	Run: func(cmd *cobra.Command, args []string) {
-// // 		batchInsertBidirection()
-// // 	},
-// // }
-
-// func batchInsertBidirection() {
-// 	session := cassandra_client.GetSession()
-// 	defer session.Close()
-
-// 	iter := session.Query("SELECT from_node, to_node FROM edges").Iter()
-// 	var fromNode, toNode string
-// 	batchSize := 100
-// 	batch := session.NewBatch(gocql.UnloggedBatch)
-// 	count := 0
-
-// 	for iter.Scan(&fromNode, &toNode) {
-// 		// Avoid self-loops
-// 		if fromNode == toNode {
-// 			continue
-// 		}
-
-// 		// Insert both directions with unique edge_id using Cassandra's uuid() function
-// 		batch.Query(
-// 			"INSERT INTO edges_bidirectional (from_node, to_node, edge_id) VALUES (?, ?, uuid())",
-// 			fromNode, toNode,
-// 		)
-// 		batch.Query(
-// 			"INSERT INTO edges_bidirectional (from_node, to_node, edge_id) VALUES (?, ?, uuid())",
-// 			toNode, fromNode,
-// 		)
-
-// 		count += 2
-// 		if count >= batchSize {
-// 			if err := session.ExecuteBatch(batch); err != nil {
-// 				log.Fatalf("Batch insert failed: %v", err.Error())
-// 			}
-// 			batch = session.NewBatch(gocql.UnloggedBatch)
-// 			count = 0
-// 		}
-// 	}
-
-// 	if count > 0 {
-// 		if err := session.ExecuteBatch(batch); err != nil {
-// 			log.Fatalf("Final batch insert failed: %v", err)
-// 		}
-// 	}
-
-// 	if err := iter.Close(); err != nil {
-// 		log.Printf("Error closing iterator: %v", err)
-// 	}
-
-// 	fmt.Println("Bidirectional edges inserted successfully.")
-// }
-// func main(){
-// 	batchInsertBidirection()
-// }
+import (
+	"context"
+	"log"
+	"math"
+	"time"
+
+	"github.com/DavidZaya21/parser/model"
+	"github.com/fatih/color"
+	"github.com/gocql/gocql"
+	"github.com/spf13/cobra"
+)
+
+const (
+	bidirectionalTask = "rebuild_bidirectional"
+	byRelationTask    = "rebuild_by_relation"
+)
+
+var (
+	IndexResume bool
+
+	IndexCmd = &cobra.Command{
+		Use:   "index",
+		Short: "Maintain tables derived from edges",
+	}
+	EdgeBidirectionCmd = &cobra.Command{
+		Use:   "rebuild-bidirectional",
+		Short: "Backfill edges_bidirectional from the edges table",
+		Long: `Backfill edges_bidirectional from the edges table.
+
+The parser fills edges_bidirectional as it loads, so this is only needed for
+keyspaces loaded by an older parser. Edges are read in token order and
+progress is stored in load_progress, so an interrupted run continues where it
+stopped with --resume.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			batchInsertBidirection(cmd.Context())
+		},
+	}
+	EdgeByRelationCmd = &cobra.Command{
+		Use:   "rebuild-by-relation",
+		Short: "Backfill edges_by_relation from the edges table",
+		Long: `Backfill edges_by_relation from the edges table.
+
+The parser fills edges_by_relation as it loads, so this is only needed for
+keyspaces loaded before the table existed. It resumes like
+rebuild-bidirectional. With --backend=local it upgrades a data directory
+written by an older parser, which read-only commands refuse to open.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			batchInsertByRelation(cmd.Context())
+		},
+	}
+)
+
+func init() {
+	IndexCmd.PersistentFlags().BoolVar(&IndexResume, "resume", false, "Continue from the last recorded progress")
+	IndexCmd.AddCommand(EdgeBidirectionCmd)
+	IndexCmd.AddCommand(EdgeByRelationCmd)
+}
+
+func batchInsertBidirection(ctx context.Context) {
+	rebuildIndex(ctx, connectCassandra(), bidirectionalTask, "edges_bidirectional", func(batch *gocql.Batch, e model.Edge, _ gocql.UUID) {
+		// Avoid self-loops
+		if e.FromNode != e.ToNode {
+			pair := gocql.UUID(model.PairID(e.FromNode, e.ToNode))
+			batch.Query("INSERT INTO edges_bidirectional (from_node, to_node, edge_id) VALUES (?, ?, ?)", e.FromNode, e.ToNode, pair)
+			batch.Query("INSERT INTO edges_bidirectional (from_node, to_node, edge_id) VALUES (?, ?, ?)", e.ToNode, e.FromNode, pair)
+		}
+	})
+}
+
+func batchInsertByRelation(ctx context.Context) {
+	if Config.Backend == BackendLocal {
+		// Opening the directory writable fills in edges_by_relation.
+		openWritableStore(ctx).Close()
+		color.Green("✅ edges_by_relation is up to date in %s", Config.DataDir)
+		return
+	}
+	rebuildIndex(ctx, connectCassandra(), byRelationTask, "edges_by_relation", func(batch *gocql.Batch, e model.Edge, id gocql.UUID) {
+		batch.Query("INSERT INTO edges_by_relation (from_node, relation, to_node, edge_id, kgtk_id, relation_label, source, sentence) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+			e.FromNode, e.RelationType, e.ToNode, id, e.ID, e.RelationLabel, e.Source, e.Sentence)
+	})
+}
+
+// rebuildIndex reads every edge in token order and lets add queue the rows
+// of table it derives from the edge, recording progress under task in
+// load_progress as batches are written. Cancelling ctx stops it between
+// batches with the progress so far recorded.
+func rebuildIndex(ctx context.Context, session *gocql.Session, task, table string, add func(batch *gocql.Batch, e model.Edge, id gocql.UUID)) {
+	lastToken := int64(math.MinInt64)
+	var rows int64
+	if IndexResume {
+		var complete bool
+		err := session.Query(`SELECT last_token, rows_done, complete FROM load_progress WHERE task = ?`, task).
+			WithContext(ctx).Scan(&lastToken, &rows, &complete)
+		switch {
+		case err == gocql.ErrNotFound:
+			color.Yellow("⚠️ No recorded progress, starting from the beginning")
+		case err != nil:
+			log.Fatalf("❌ Failed to read progress: %v", err)
+		case complete:
+			color.Green("✅ %s was already rebuilt (%d edges)", table, rows)
+			return
+		default:
+			color.Yellow("⏩ Resuming at %.1f%% after %d edges", tokenProgress(lastToken), rows)
+		}
+	}
+
+	startTime := time.Now()
+	iter := session.Query(`SELECT token(from_node), from_node, to_node, relation, edge_id, kgtk_id, relation_label, source, sentence FROM edges WHERE token(from_node) > ?`, lastToken).
+		PageSize(5000).WithContext(ctx).Iter()
+
+	batch := session.NewBatch(gocql.UnloggedBatch).WithContext(ctx)
+	flush := func(completed, completedRows int64) {
+		if batch.Size() > 0 {
+			if err := executeWithRetry(ctx, session, batch); err != nil {
+				log.Fatalf("❌ Batch insert failed, rerun with --resume: %v", err)
+			}
+			batch = session.NewBatch(gocql.UnloggedBatch).WithContext(ctx)
+		}
+		saveIndexProgress(ctx, session, task, completed, completedRows, false)
+	}
+
+	// A partition can span pages, so progress only ever records the token of
+	// a partition that has been read to the end, with the edges up to it; a
+	// resumed run reads the rest of a partially read one again.
+	var token int64
+	var edge model.Edge
+	var id gocql.UUID
+	current, completed := lastToken, lastToken
+	completedRows := rows
+	for iter.Scan(&token, &edge.FromNode, &edge.ToNode, &edge.RelationType, &id, &edge.ID, &edge.RelationLabel, &edge.Source, &edge.Sentence) {
+		if token != current {
+			completed, current = current, token
+			completedRows = rows
+		}
+		rows++
+
+		add(batch, edge, id)
+		if batch.Size() >= Config.BatchSize {
+			if ctx.Err() != nil {
+				break
+			}
+			flush(completed, completedRows)
+		}
+		if rows%100000 == 0 {
+			color.Cyan("📈 %d edges processed, %.1f%% of the token ring, %s elapsed", rows, tokenProgress(current), time.Since(startTime).Round(time.Second))
+		}
+	}
+	if ctx.Err() != nil {
+		iter.Close()
+		log.Fatalf("❌ Interrupted at %.1f%% after %d edges, rerun with --resume", tokenProgress(completed), completedRows)
+	}
+	if err := iter.Close(); err != nil {
+		flush(completed, completedRows)
+		log.Fatalf("❌ Error reading edges, rerun with --resume: %v", err)
+	}
+
+	flush(completed, completedRows)
+	saveIndexProgress(ctx, session, task, math.MaxInt64, rows, true)
+	color.Green("✅ %s rows inserted for %d edges in %s", table, rows, time.Since(startTime))
+}
+
+// saveIndexProgress records progress even once ctx is cancelled, so an
+// interrupted rebuild resumes from its last written batch.
+func saveIndexProgress(ctx context.Context, session *gocql.Session, task string, lastToken, rows int64, complete bool) {
+	err := session.Query(`INSERT INTO load_progress (task, last_token, rows_done, complete, updated_at) VALUES (?, ?, ?, ?, ?)`,
+		task, lastToken, rows, complete, time.Now()).WithContext(context.WithoutCancel(ctx)).Exec()
+	if err != nil {
+		log.Printf("⚠️ Failed to save progress: %v", err)
+	}
+}
+
+func executeWithRetry(ctx context.Context, session *gocql.Session, batch *gocql.Batch) error {
+	var err error
+	for attempt := 1; attempt <= 5; attempt++ {
+		if err = session.ExecuteBatch(batch); err == nil {
+			return nil
+		}
+		log.Printf("⚠️ Batch insert failed (attempt %d): %v", attempt, err)
+		select {
+		case <-time.After(time.Duration(attempt) * time.Second):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return err
+}
+
+// tokenProgress converts a Murmur3 token into how far through the ring it
+// is, as a percentage. Tokens are uniformly distributed, so this tracks the
+// share of edges processed.
+func tokenProgress(token int64) float64 {
+	return (float64(token)/math.MaxInt64 + 1) * 50
+}
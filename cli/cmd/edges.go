@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/DavidZaya21/parser/model"
+)
+
+// edgeDetail is one edge as seen from the node being queried: Node is the
+// node at the other end, the rest is the KGTK payload kept by the loader.
+type edgeDetail struct {
+	Node          string `json:"node"`
+	Relation      string `json:"relation"`
+	RelationLabel string `json:"relation_label"`
+	Source        string `json:"source"`
+	Sentence      string `json:"sentence"`
+}
+
+// detail returns e as seen from the node across from other.
+func detail(other string, e model.Edge) edgeDetail {
+	return edgeDetail{
+		Node:          other,
+		Relation:      e.RelationType,
+		RelationLabel: e.RelationLabel,
+		Source:        e.Source,
+		Sentence:      e.Sentence,
+	}
+}
+
+func (e edgeDetail) String() string {
+	relation := e.Relation
+	if e.RelationLabel != "" {
+		relation = fmt.Sprintf("%s (%s)", e.Relation, e.RelationLabel)
+	}
+	line := fmt.Sprintf("Node: %s, Relation: %s, Source: %s", e.Node, relation, orNone(e.Source))
+	if e.Sentence != "" {
+		line += fmt.Sprintf(", Sentence: %s", e.Sentence)
+	}
+	return line
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
+
+func formatLabels(labels []string) string {
+	if len(labels) == 0 {
+		return "(no label found)"
+	}
+	return strings.Join(labels, " | ")
+}
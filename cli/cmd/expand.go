@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"context"
+	"log"
+
+	"github.com/DavidZayar/cli/graph"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	ExpandHops        int
+	ExpandDirection   string
+	ExpandMaxFrontier int
+	ExpandConcurrency int
+	ExpandBatchSize   int
+	ExpandRevisit     bool
+
+	ExpandCmd = &cobra.Command{
+		Use:   "expand <node>",
+		Short: color.GreenString("Expand the neighbourhood of a node hop by hop"),
+		Long: `Expand the neighbourhood of a node one level at a time, following edges
+--direction out (successors), in (predecessors) or both, for --hops levels.
+Every node of a level is looked up before the next one starts; the lookups
+of a level run --concurrency batches of --batch-size nodes at once.
+
+A node is listed at the first hop that reaches it, and each level keeps at
+most --max-frontier nodes, the first in name order, so a hub cannot make
+the next level explode. With --revisit a level holds every node at the end
+of a path of exactly that many hops, even if an earlier one reached it:
+seven is expand --hops=2 --revisit and eight the same with --direction=in.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			ExpandAction(cmd.Context(), args[0])
+		},
+	}
+)
+
+func ExpandAction(ctx context.Context, node string) {
+	switch ExpandDirection {
+	case graph.Out, graph.In, graph.Both:
+	default:
+		log.Fatalf("❌ Unknown --direction %q, expected %s, %s or %s", ExpandDirection, graph.Out, graph.In, graph.Both)
+	}
+	if ExpandHops < 1 {
+		log.Fatalf("❌ --hops must be at least 1, got %d", ExpandHops)
+	}
+	opts := graph.ExpandOptions{
+		Hops:        ExpandHops,
+		Direction:   ExpandDirection,
+		Relations:   relations(),
+		MaxFrontier: ExpandMaxFrontier,
+		Concurrency: ExpandConcurrency,
+		BatchSize:   ExpandBatchSize,
+		Revisit:     ExpandRevisit,
+	}
+
+	st := openStore(ctx)
+	defer st.Close()
+
+	color.Cyan("🔍 Expanding %s, %d hops %s...", node, ExpandHops, ExpandDirection)
+	hops, err := graph.Expand(ctx, st, node, opts)
+	if err != nil {
+		log.Fatalf("❌ Error expanding %s: %v", node, err)
+	}
+
+	out := newResults()
+	total := 0
+	for _, hop := range hops {
+		total += hop.Reached
+		if out.Table() {
+			show(color.FgCyan, "📌 Hop %d: %d nodes from %d edges", hop.Depth, hop.Reached, hop.Edges)
+			for _, n := range hop.Nodes {
+				show(color.FgGreen, "    %s", n)
+			}
+			if hop.Truncated {
+				show(color.FgYellow, "⚠️ Kept the first %d of %d nodes (--max-frontier)", len(hop.Nodes), hop.Reached)
+			}
+		}
+		out.Add(hopRecord{
+			Hop:       hop.Depth,
+			Count:     hop.Reached,
+			Edges:     hop.Edges,
+			Truncated: hop.Truncated,
+			Nodes:     append([]string{}, hop.Nodes...),
+		})
+	}
+	// With --revisit a node can be counted on several hops.
+	if out.Table() && !ExpandRevisit {
+		show(color.FgCyan, "📌 Nodes within %d hops of %s: %d", ExpandHops, node, total)
+	}
+	out.Flush()
+	color.Green("✅ Expansion completed successfully.")
+}
+
+// hopRecord is one level of an expansion. Count is the nodes the level
+// reached and Nodes the ones kept under --max-frontier.
+type hopRecord struct {
+	Hop       int      `json:"hop"`
+	Count     int      `json:"count"`
+	Edges     int      `json:"edges"`
+	Truncated bool     `json:"truncated"`
+	Nodes     []string `json:"nodes"`
+}
+
+func init() {
+	ExpandCmd.Flags().IntVar(&ExpandHops, "hops", 2, "How many levels to expand")
+	ExpandCmd.Flags().StringVar(&ExpandDirection, "direction", graph.Out, "Follow edges out, in or both")
+	ExpandCmd.Flags().IntVar(&ExpandMaxFrontier, "max-frontier", 10000, "Most nodes kept at each level (0 for no cap)")
+	ExpandCmd.Flags().IntVar(&ExpandConcurrency, "concurrency", graph.DefaultExpandConcurrency, "Batches of a level looked up at once")
+	ExpandCmd.Flags().IntVar(&ExpandBatchSize, "batch-size", graph.DefaultExpandBatchSize, "Nodes a worker looks up per batch")
+	ExpandCmd.Flags().BoolVar(&ExpandRevisit, "revisit", false, "Let a level hold nodes an earlier hop reached")
+	addRelationFlag(ExpandCmd)
+}
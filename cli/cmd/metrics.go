@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/DavidZayar/cli/metrics"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// Formats accepted by --metrics. Given without a value it means text.
+const (
+	MetricsOff  = "off"
+	MetricsText = "text"
+	MetricsJSON = "json"
+)
+
+var (
+	MetricsFormat string
+	TimesFile     string
+)
+
+// checkMetricsFormat rejects an unknown --metrics before any query runs.
+func checkMetricsFormat() {
+	switch MetricsFormat {
+	case MetricsOff, MetricsText, MetricsJSON:
+	default:
+		log.Fatalf("❌ Unknown metrics format %q, expected %s, %s or %s",
+			MetricsFormat, MetricsOff, MetricsText, MetricsJSON)
+	}
+}
+
+// measured wraps a query so every run is measured the same way. The query
+// runs with a context that counts its store calls, its wall time is logged to
+// --times-file when one is given and, with --metrics, the report goes to
+// stderr so it never mixes with the results.
+func measured(run func(cmd *cobra.Command, args []string)) func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		name := "query_" + cmd.Name()
+		ctx, m := metrics.Start(cmd.Context(), name)
+		cmd.SetContext(ctx)
+		run(cmd, args)
+		report := m.Stop()
+		if TimesFile != "" {
+			logQueryTime(TimesFile, report.Wall(), name)
+		}
+		printMetrics(report)
+	}
+}
+
+func printMetrics(r metrics.Report) {
+	switch MetricsFormat {
+	case MetricsText:
+		color.Cyan("⏱️ Wall Time: %.3f ms", r.WallMs)
+		color.Cyan("⚙️ CPU Time: user %.3f ms, sys %.3f ms", r.CPUUserMs, r.CPUSysMs)
+		color.Cyan("🧠 Allocated: %.2f MB in %d allocations (heap %+.2f MB)",
+			mb(r.AllocBytes), r.Mallocs, float64(r.HeapDelta)/1024/1024)
+		color.Cyan("🧹 GC: %d cycles, %.3f ms paused", r.GCCycles, r.GCPauseMs)
+		color.Cyan("📡 Store: %d calls, %d rows scanned, %d Cassandra round trips (%d rows)",
+			r.StoreCalls, r.RowsScanned, r.RoundTrips, r.CassandraRows)
+		color.Cyan("📈 Throughput: %.2f rows/sec", r.RowsPerSec)
+	case MetricsJSON:
+		data, err := json.Marshal(r)
+		if err != nil {
+			log.Printf("⚠️ Failed to encode metrics: %v", err)
+			return
+		}
+		fmt.Fprintln(os.Stderr, string(data))
+	}
+}
+
+func mb(bytes uint64) float64 {
+	return float64(bytes) / 1024 / 1024
+}
+
+func logQueryTime(path string, duration time.Duration, queryNumber string) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		log.Printf("Warning: Could not open %s: %v", path, err)
+		return
+	}
+	defer file.Close()
+
+	timestamp := time.Now().Format("2006-01-02 15:04:05")
+	logEntry := fmt.Sprintf("%s, %s, %s\n", queryNumber, timestamp, duration)
+
+	if _, err := file.WriteString(logEntry); err != nil {
+		log.Printf("Warning: Could not write to %s: %v", path, err)
+	}
+}
@@ -0,0 +1,231 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/DavidZayar/cli/store"
+	"github.com/fatih/color"
+)
+
+// Formats accepted by --output.
+const (
+	OutputTable  = "table"
+	OutputJSON   = "json"
+	OutputNDJSON = "ndjson"
+	OutputCSV    = "csv"
+	OutputTSV    = "tsv"
+)
+
+var OutputFormat string
+
+// checkOutputFormat rejects an unknown --output before any query runs.
+func checkOutputFormat() {
+	switch OutputFormat {
+	case OutputTable, OutputJSON, OutputNDJSON, OutputCSV, OutputTSV:
+	default:
+		log.Fatalf("❌ Unknown output format %q, expected %s, %s, %s, %s or %s",
+			OutputFormat, OutputTable, OutputJSON, OutputNDJSON, OutputCSV, OutputTSV)
+	}
+}
+
+// results is where a command's answer goes. With --output=table commands
+// print it for people with show; with any other format they hand records to
+// Add or Object and the answer goes to stdout in that format. Diagnostics and
+// metrics are printed with the color package, which writes to stderr.
+type results struct {
+	format  string
+	out     *bufio.Writer
+	csv     *csv.Writer
+	columns []string
+	items   []any
+
+	// paged is set by Next, for a command that printed one page.
+	paged bool
+	next  string
+}
+
+func newResults() *results {
+	r := &results{format: OutputFormat, out: bufio.NewWriter(os.Stdout)}
+	switch r.format {
+	case OutputCSV:
+		r.csv = csv.NewWriter(r.out)
+	case OutputJSON:
+		r.items = []any{}
+	}
+	return r
+}
+
+// Table reports whether the command should print its human-readable form.
+func (r *results) Table() bool {
+	return r.format == OutputTable
+}
+
+// Add writes one record of a list. Records are structs; their json tags name
+// the fields and the CSV/TSV columns.
+func (r *results) Add(record any) {
+	switch r.format {
+	case OutputJSON:
+		r.items = append(r.items, record)
+	case OutputNDJSON:
+		r.writeJSON(record)
+	case OutputCSV, OutputTSV:
+		r.writeRow(record)
+	}
+}
+
+// Object writes a result that is a single record, such as a count. JSON
+// prints it as an object rather than an array of one.
+func (r *results) Object(record any) {
+	if r.format == OutputJSON {
+		r.writeJSON(record)
+		r.items = nil
+		return
+	}
+	r.Add(record)
+}
+
+// Next records that the records were one page and next is the cursor of
+// the following one, "" after the last page. Tables print it after the
+// rows and JSON wraps the rows as {"items": [...], "next_cursor": "..."};
+// the other formats keep stdout to records and report it on stderr.
+func (r *results) Next(next string) {
+	r.paged, r.next = true, next
+	switch {
+	case r.Table() && next != "":
+		show(color.FgCyan, "➡️  More on the next page: --cursor=%s", next)
+	case r.Table():
+		show(color.FgCyan, "✅ Last page")
+	case r.format != OutputJSON && next != "":
+		color.Cyan("➡️  More on the next page: --cursor=%s", next)
+	}
+}
+
+// Flush writes anything still buffered. Commands call it once, after the
+// last record.
+func (r *results) Flush() {
+	if r.format == OutputJSON && r.paged {
+		r.writeJSON(struct {
+			Items      []any  `json:"items"`
+			NextCursor string `json:"next_cursor,omitempty"`
+		}{r.items, r.next})
+	} else if r.format == OutputJSON && r.items != nil {
+		r.writeJSON(r.items)
+	}
+	if r.csv != nil {
+		r.csv.Flush()
+	}
+	if err := r.out.Flush(); err != nil {
+		log.Fatalf("❌ Failed to write results: %v", err)
+	}
+}
+
+func (r *results) writeJSON(v any) {
+	enc := json.NewEncoder(r.out)
+	enc.SetEscapeHTML(false)
+	if r.format == OutputJSON {
+		enc.SetIndent("", "  ")
+	}
+	if err := enc.Encode(v); err != nil {
+		log.Fatalf("❌ Failed to encode results: %v", err)
+	}
+}
+
+func (r *results) writeRow(record any) {
+	columns, values := fields(record)
+	if r.columns == nil {
+		r.columns = columns
+		r.writeCells(columns)
+	}
+	r.writeCells(values)
+}
+
+func (r *results) writeCells(cells []string) {
+	if r.csv != nil {
+		if err := r.csv.Write(cells); err != nil {
+			log.Fatalf("❌ Failed to write results: %v", err)
+		}
+		return
+	}
+	for i, cell := range cells {
+		if i > 0 {
+			r.out.WriteByte('\t')
+		}
+		r.out.WriteString(tsvEscaper.Replace(cell))
+	}
+	r.out.WriteByte('\n')
+}
+
+// tsvEscaper keeps every record on one line with one tab between cells.
+var tsvEscaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+
+// fields flattens a record struct into column names and cell values. Lists
+// such as labels and paths become JSON arrays, so a cell can hold any name.
+func fields(record any) (columns, values []string) {
+	v := reflect.Indirect(reflect.ValueOf(record))
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name == "-" || !t.Field(i).IsExported() {
+			continue
+		}
+		if name == "" {
+			name = t.Field(i).Name
+		}
+		columns = append(columns, name)
+		values = append(values, cell(v.Field(i)))
+	}
+	return columns, values
+}
+
+func cell(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Slice, reflect.Map, reflect.Struct:
+		data, err := json.Marshal(v.Interface())
+		if err != nil {
+			return ""
+		}
+		return string(data)
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
+
+// show prints one line of table output to stdout in c's colour.
+func show(c color.Attribute, format string, a ...any) {
+	line := fmt.Sprintf(format, a...)
+	if !strings.HasSuffix(line, "\n") {
+		line += "\n"
+	}
+	color.New(c).Fprint(os.Stdout, line)
+}
+
+// nodeRecord is a node in a result list.
+type nodeRecord struct {
+	Node   string   `json:"node"`
+	Labels []string `json:"labels"`
+}
+
+// addNodes looks up the labels of each node and adds it to r. A failed label
+// lookup is reported and the node is still listed.
+func addNodes(ctx context.Context, st store.GraphStore, r *results, nodes []string) {
+	for _, node := range nodes {
+		labels, err := st.Labels(ctx, node)
+		if err != nil {
+			log.Printf("⚠️ Failed to read labels of %s: %v", node, err)
+		}
+		if labels == nil {
+			labels = []string{}
+		}
+		r.Add(nodeRecord{Node: node, Labels: labels})
+	}
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"errors"
+	"log"
+
+	"github.com/DavidZayar/cli/store"
+	"github.com/spf13/cobra"
+)
+
+// Paging flags, shared by the listing commands one, three and five.
+var (
+	PageSize   int
+	PageCursor string
+)
+
+func addPagingFlags(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&PageSize, "page-size", 0, "Print at most this many rows and a cursor for the rest (default everything)")
+	cmd.Flags().StringVar(&PageCursor, "cursor", "", "Continue from the cursor a previous page printed")
+}
+
+// paging reports whether the command was asked for one page rather than
+// the whole answer.
+func paging() bool {
+	return PageSize > 0 || PageCursor != ""
+}
+
+func page() store.Page {
+	return store.Page{Size: PageSize, Cursor: PageCursor}
+}
+
+// checkPage stops on a failed page read, telling a cursor from another
+// query or node apart from a store failure.
+func checkPage(err error) {
+	if errors.Is(err, store.ErrBadCursor) {
+		log.Fatal("❌ --cursor was not printed by this command for this node; leave it out to start from the first page")
+	}
+	if err != nil {
+		log.Fatalf("❌ Error reading results: %v", err)
+	}
+}
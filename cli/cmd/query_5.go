@@ -1,14 +1,11 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"log"
-	"runtime"
-	"runtime/debug"
-	"syscall"
-	"time"
 
-	"github.com/DavidZayar/cli/cassandra_client"
+	"github.com/DavidZayar/cli/graph"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
@@ -20,88 +17,70 @@ var (
 		Aliases: []string{"five"},
 		Short:   color.GreenString("Find all the neighbors of given node"),
 		Run: func(cmd *cobra.Command, args []string) {
-			QueryFiveAction()
+			QueryFiveAction(cmd.Context())
 		},
 	}
 )
 
-func QueryFiveAction() {
+func QueryFiveAction(ctx context.Context) {
 	if QueryFiveNode == "" {
 		log.Fatal("❌ You must provide a --node value")
 	}
 
-	color.Yellow("🔌 Creating the Session")
-	session := cassandra_client.GetSession()
-	defer session.Close()
-
-	startTime := time.Now()
-	var rusageStart syscall.Rusage
-	_ = syscall.Getrusage(syscall.RUSAGE_SELF, &rusageStart)
-
-	var memStart runtime.MemStats
-	runtime.ReadMemStats(&memStart)
-
-	neighbors := make(map[string]bool)
-	var count, skipped int
+	rel := relations()
+	if paging() && !rel.All() {
+		log.Fatal("❌ Pages of five come from edges_bidirectional, which keeps no relations; leave out --page-size and --cursor to filter by --relation")
+	}
+	st := openStore(ctx)
+	defer st.Close()
 
-	color.Cyan("🔍 Querying successors...")
-	succIter := session.Query(fmt.Sprintf("SELECT to_node FROM edges WHERE from_node = '%s';", QueryFiveNode)).Iter()
-	var toNode string
-	for succIter.Scan(&toNode) {
-		if toNode != QueryFiveNode {
-			if !neighbors[toNode] {
-				neighbors[toNode] = true
-				count++
-			}
-		} else {
-			skipped++
+	if grouping() {
+		color.Cyan("🔍 Querying successors and predecessors...")
+		groups, err := graph.NeighborsByRelation(ctx, st, QueryFiveNode, rel)
+		if err != nil {
+			log.Fatalf("❌ Error reading neighbors: %v", err)
 		}
+		showGroups(fmt.Sprintf("📋 Neighbors of node '%s' by relation:", QueryFiveNode), groups, true)
+		color.Green("✅ Neighbor query completed successfully.")
+		return
 	}
-	if err := succIter.Close(); err != nil {
-		log.Fatalf("❌ Error reading successors: %v", err)
-	}
-	color.Cyan("🔍 Querying predecessors...")
-	predIter := session.Query(fmt.Sprintf("SELECT from_node FROM edges WHERE to_node = '%s' ALLOW FILTERING;", QueryFiveNode)).Iter()
-	var fromNode string
-	for predIter.Scan(&fromNode) {
-		if fromNode != QueryFiveNode {
-			if !neighbors[fromNode] {
-				neighbors[fromNode] = true
-				count++
+
+	out := newResults()
+	if paging() {
+		// One page of edges_bidirectional, in its order.
+		color.Cyan("🔍 Querying one page of neighbors...")
+		neighbors, next, err := st.NeighborsPage(ctx, QueryFiveNode, page())
+		checkPage(err)
+		if out.Table() {
+			show(color.FgGreen, "📋 Neighbors:")
+			for _, n := range neighbors {
+				show(color.FgGreen, "%s", n)
 			}
 		} else {
-			skipped++
+			addNodes(ctx, st, out, neighbors)
 		}
+		out.Next(next)
+		out.Flush()
+		color.Green("✅ Neighbor query completed successfully.")
+		return
 	}
-	if err := predIter.Close(); err != nil {
-		log.Fatalf("❌ Error reading predecessors: %v", err)
-	}
-
-	endTime := time.Now()
-	var rusageEnd syscall.Rusage
-	_ = syscall.Getrusage(syscall.RUSAGE_SELF, &rusageEnd)
 
-	var memEnd runtime.MemStats
-	runtime.ReadMemStats(&memEnd)
-	debug.FreeOSMemory()
-
-	duration := endTime.Sub(startTime)
-	cpuUserTime := time.Duration(rusageEnd.Utime.Nano() - rusageStart.Utime.Nano())
-	cpuSysTime := time.Duration(rusageEnd.Stime.Nano() - rusageStart.Stime.Nano())
-	memUsed := memEnd.Alloc - memStart.Alloc
-	gcPauseNs := memEnd.PauseTotalNs - memStart.PauseTotalNs
-	throughput := float64(count) / duration.Seconds()
-	logQueryTime(duration, "query_five")
-	color.Green("✅ Neighbor query completed successfully.")
-	color.Cyan("📌 Total unique neighbors: %d | Skipped: %d", count, skipped)
-	color.Green("📋 Neighbors:")
-	for n := range neighbors {
-		color.Green("%s \n", n)
+	color.Cyan("🔍 Querying successors and predecessors...")
+	neighbors, skipped, err := graph.Neighbors(ctx, st, QueryFiveNode, rel)
+	if err != nil {
+		log.Fatalf("❌ Error reading neighbors: %v", err)
 	}
+	count := len(neighbors)
 
-	color.Yellow("⏱️  Wall Time: %s", duration)
-	color.Yellow("⚙️  CPU Time (User): %s | (Sys): %s", cpuUserTime, cpuSysTime)
-	color.Magenta("🧠 Memory Used: %.2f KB", float64(memUsed)/1024)
-	color.Blue("🧹 GC Pause: %.2f ms", float64(gcPauseNs)/1e6)
-	color.Cyan("📈 Throughput: %.2f rows/sec", throughput)
+	if out.Table() {
+		show(color.FgCyan, "📌 Total unique neighbors: %d | Skipped: %d", count, skipped)
+		show(color.FgGreen, "📋 Neighbors:")
+		for _, n := range neighbors {
+			show(color.FgGreen, "%s", n)
+		}
+	} else {
+		addNodes(ctx, st, out, neighbors)
+	}
+	out.Flush()
+	color.Green("✅ Neighbor query completed successfully.")
 }
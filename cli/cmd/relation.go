@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/DavidZayar/cli/graph"
+	"github.com/DavidZayar/cli/store"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// GroupByRelation is the one grouping --group-by knows.
+const GroupByRelation = "relation"
+
+// Relation flags, shared by the neighbourhood queries one to eight and
+// expand.
+var (
+	RelationPatterns []string
+	GroupBy          string
+)
+
+func addRelationFlag(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&RelationPatterns, "relation", nil, "Only edges of these relations: /r/IsA, a prefix like /r/*, or -/r/Synonym to leave one out (repeatable)")
+}
+
+func addGroupByFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&GroupBy, "group-by", "", "Group the answer by relation, with a count and the nodes of each: relation")
+}
+
+// relations parses --relation, stopping on a bad pattern.
+func relations() store.Relations {
+	rel, err := store.ParseRelations(RelationPatterns)
+	if err != nil {
+		log.Fatalf("❌ --relation: %v", err)
+	}
+	return rel
+}
+
+// grouping reports whether the command was asked for --group-by=relation.
+// Groups need every edge, so they do not mix with paging.
+func grouping() bool {
+	switch GroupBy {
+	case "":
+		return false
+	case GroupByRelation:
+		if paging() {
+			log.Fatal("❌ --group-by needs the whole answer; leave out --page-size and --cursor")
+		}
+		return true
+	}
+	log.Fatalf("❌ Unknown --group-by %q, expected %s", GroupBy, GroupByRelation)
+	return false
+}
+
+// relationGroupRecord is one relation of a grouped list.
+type relationGroupRecord struct {
+	Relation string   `json:"relation"`
+	Count    int      `json:"count"`
+	Nodes    []string `json:"nodes"`
+}
+
+// relationCountRecord is one relation of a grouped count.
+type relationCountRecord struct {
+	Relation string `json:"relation"`
+	Count    int    `json:"count"`
+}
+
+// showGroups prints the groups of a --group-by=relation answer, with their
+// nodes for the listing queries or as counts alone for the counting ones.
+func showGroups(title string, groups []graph.RelationGroup, withNodes bool) {
+	out := newResults()
+	if out.Table() {
+		show(color.FgWhite, "%s", title)
+		for _, g := range groups {
+			show(color.FgCyan, "📂 %s: %d", g.Relation, len(g.Nodes))
+			if withNodes {
+				for _, n := range g.Nodes {
+					show(color.FgGreen, "    %s", n)
+				}
+			}
+		}
+		show(color.FgCyan, "📌 Relations: %d", len(groups))
+	}
+	for _, g := range groups {
+		if withNodes {
+			out.Add(relationGroupRecord{Relation: g.Relation, Count: len(g.Nodes), Nodes: g.Nodes})
+		} else {
+			out.Add(relationCountRecord{Relation: g.Relation, Count: len(g.Nodes)})
+		}
+	}
+	out.Flush()
+}
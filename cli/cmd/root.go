@@ -1,6 +1,11 @@
 package cmd
 
 import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -8,7 +13,6 @@ import (
 
 var queries = []*cobra.Command{
 	// QueryDummyCmd,
-	// EdgeBidirectionCmd,
 	QueryOneCmd,
 	QueryTwoCmd,
 	QueryThreeCmd,
@@ -27,6 +31,7 @@ var queries = []*cobra.Command{
 	QuerySixteenCmd,
 	QuerySeventeenCmd,
 	QueryEighteenCmd,
+	ExpandCmd,
 }
 var rootCmd = &cobra.Command{
 	Use:   "dbcli",
@@ -36,13 +41,20 @@ var rootCmd = &cobra.Command{
 Available commands and flags:
 
   one         -f, --from_node       Find successors of a given node
+              --source              Only edges from these sources (CN, WD, ...)
+              --page-size, --cursor One page at a time, and the next page's cursor
   two         -f, --from_node       Count successors of a given node
   three       -f, --to_node         Find predecessors of a given node
+              --source              Only edges from these sources (CN, WD, ...)
+              --page-size, --cursor One page at a time, and the next page's cursor
   four        -f, --to_node         Count predecessors of a given node
   five        -f, --node            Find neighbors of a given node
+              --page-size, --cursor One page at a time, and the next page's cursor
   six         -f, --node            Count neighbors of a given node
   seven       -f, --node            Find grandchildren of a given node
   eight       -f, --node            Find grandparents of a given node
+  one to eight  --relation          Only these relations: /r/IsA, /r/*, -/r/Synonym
+              --group-by=relation   Count and list the nodes of each relation
   nine                            Count all nodes in the graph (no flags)
   ten                             Count nodes without successors (no flags)
   eleven                          Count nodes without predecessors (no flags)
@@ -54,16 +66,79 @@ Available commands and flags:
   sixteen     [source] [target]     Find shortest path between two nodes
   seventeen   [node] [depth]        Find distant synonyms
   eighteen    [node] [depth]        Find distant antonyms
+  expand      [node]                Expand a neighbourhood hop by hop
+              --hops, --direction   Levels, and out, in or both
+              --relation            Only these relations on every hop
+              --max-frontier        Most nodes kept per level
+              --concurrency, --batch-size, --revisit
+
+Global flags:
+
+  --config                          YAML file with the settings below ($GRAPH_CONFIG)
+  --backend                         cassandra (default), local or memory
+  --data-dir                        Parser output to read with --backend=local
+  --tsv                             KGTK file to load with --backend=memory
+  --output                          table (default), json, ndjson, csv or tsv
+  --metrics[=text|json]             Report time, memory and store work to stderr
+  --hosts, --port, --keyspace       Cassandra contact points and keyspace
+  --username, --password            Password authentication
+  --tls-ca, --tls-cert, --tls-key   Client-to-node encryption
+  --local-dc                        Prefer replicas in this datacenter
+  --consistency                     Cassandra consistency level (default ONE)
+  --timeout, --connect-timeout      Cassandra query and connection timeouts
+  --num-conns, --retries            Connections per host, retries per query
+  --compression                     Snappy compression (default true)
+
+Administration:
+
+  schema init                       Create the keyspace and apply all migrations
+  schema migrate                    Apply pending migrations
+  schema status                     Show applied and pending migrations
+  index rebuild-bidirectional       Backfill edges_bidirectional from edges
+  index rebuild-by-relation         Backfill edges_by_relation from edges,
+                                    needed once after migration 0005
+  index ... --resume                Continue an interrupted rebuild
+
+Interactive:
+
+  shell                             Run queries over one connection, with
+              --history, --writable  completion, history and $variables
+  serve                             Serve the queries over HTTP and gRPC
+              --addr, --grpc-addr, --request-timeout, --page-size, --writable
+
+Benchmarking:
+
+  bench <workload>                  Run a workload with warmups and repetitions
+              --warmups, -n, --reps, -c, --concurrency
+  bench compare <old> <new>         Compare two runs, exit 1 on a regression
+              --threshold, --alpha
 
 Examples:
 
   dbcli one -f="/c/en/steam_locomotive"
+  dbcli one -f="/c/en/jar" --source=CN,WN
+  dbcli five -f="/c/en/jar" --backend=local --data-dir=./graph-data
+  dbcli three -f="/c/en/country" --page-size=1000 --output=json
+  dbcli one -f="/c/en/jar" --relation=/r/* --relation=-/r/Synonym --group-by=relation
   dbcli fourteen -o="/c/en/transportation_topic/n" -n="/c/en/movement_topic/n"
   dbcli sixteen "/c/en/uchuva" "/c/en/square_sails/n"
   dbcli seventeen "/c/en/defeatable" 2
+  dbcli expand "/c/en/jar" --hops=3 --direction=both --relation=/r/IsA
+  dbcli nine --metrics=json
 
 Use "dbcli [command] --help" for detailed help on a command.
 `,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		// Results go to stdout, everything printed with color is a
+		// diagnostic and goes to stderr.
+		color.Output = color.Error
+		checkOutputFormat()
+		checkMetricsFormat()
+		var err error
+		if Config, err = configFlags.Load(); err != nil {
+			log.Fatalf("❌ %v", err)
+		}
+	},
 	Run: func(cmd *cobra.Command, args []string) {
 		cmd.Help()
 	},
@@ -71,24 +146,44 @@ Use "dbcli [command] --help" for detailed help on a command.
 
 
 func Exec() {
-	if err := rootCmd.Execute(); err != nil {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	defer closeCassandra()
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
 		color.Red("Root command exec is failed", err.Error())
 	}
 }
 func init() {
 	cliName := "dbcli"
 	rootCmd.Flags().Bool(cliName, false, "Help for message")
+	rootCmd.PersistentFlags().StringVar(&TSVFile, "tsv", "", "KGTK file to load with --backend=memory")
+	rootCmd.PersistentFlags().StringVar(&OutputFormat, "output", OutputTable, "Result format: table, json, ndjson, csv or tsv")
+	rootCmd.PersistentFlags().StringVar(&MetricsFormat, "metrics", MetricsOff, "Report time, memory and store work to stderr: off, text or json")
+	rootCmd.PersistentFlags().Lookup("metrics").NoOptDefVal = MetricsText
+	rootCmd.PersistentFlags().StringVar(&TimesFile, "times-file", "", "Append each query's wall time to this CSV file")
+	shared := flag.NewFlagSet(cliName, flag.ContinueOnError)
+	configFlags.Register(shared)
+	rootCmd.PersistentFlags().AddGoFlagSet(shared)
 	mountingCmd()
 	QueryOneCmd.Flags().StringVarP(&QueryOneNode, "from_node", "f", "", "Source node to find successors for")
 	_ = QueryOneCmd.MarkFlagRequired("from_node")
+	QueryOneCmd.Flags().StringSliceVar(&QueryOneSources, "source", nil, "Only show edges from these sources (CN, WD, WN, VG, ...)")
 	QueryTwoCmd.Flags().StringVarP(&QueryTwoFromNode, "from_node", "f", "", "Count all the successors of given node")
 	_ = QueryTwoCmd.MarkFlagRequired("from_node")
 	QueryThreeCmd.Flags().StringVarP(&QueryThreeNode, "to_node", "f", "", "Find all predecessors of a given node")
 	_ = QueryThreeCmd.MarkFlagRequired("to_node")
+	QueryThreeCmd.Flags().StringSliceVar(&QueryThreeSources, "source", nil, "Only show edges from these sources (CN, WD, WN, VG, ...)")
 	QueryFourCmd.Flags().StringVarP(&QueryFourNode, "to_node", "f", "", "Count all the predecessors of given node")
 	_ = QueryFourCmd.MarkFlagRequired("to_node")
 	QueryFiveCmd.Flags().StringVarP(&QueryFiveNode, "node", "f", "", "Find all neighbors of given node")
 	_ = QueryFiveCmd.MarkFlagRequired("node")
+	addPagingFlags(QueryOneCmd)
+	addPagingFlags(QueryThreeCmd)
+	addPagingFlags(QueryFiveCmd)
+	for _, cmd := range []*cobra.Command{QueryOneCmd, QueryTwoCmd, QueryThreeCmd, QueryFourCmd, QueryFiveCmd, QuerySixCmd, QuerySevenCmd, QueryEightCmd} {
+		addRelationFlag(cmd)
+		addGroupByFlag(cmd)
+	}
 	QuerySixCmd.Flags().StringVarP(&QuerySixNode, "node", "f", "", "Count all neighbors of given node")
 	_ = QuerySixCmd.MarkFlagRequired("node")
 	QuerySevenCmd.Flags().StringVarP(&QuerySevenNode, "node", "f", "", "Find all grandchildren of given node")
@@ -105,6 +200,12 @@ func init() {
 
 func mountingCmd() {
 	for _, cmd := range queries {
+		cmd.Run = measured(cmd.Run)
 		rootCmd.AddCommand(cmd)
 	}
+	rootCmd.AddCommand(SchemaCmd)
+	rootCmd.AddCommand(IndexCmd)
+	rootCmd.AddCommand(BenchCmd)
+	rootCmd.AddCommand(ShellCmd)
+	rootCmd.AddCommand(ServeCmd)
 }
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/DavidZayar/cli/schema"
+	"github.com/fatih/color"
+	"github.com/gocql/gocql"
+	"github.com/spf13/cobra"
+)
+
+var (
+	SchemaReplicationClass  string
+	SchemaReplicationFactor int
+	SchemaDatacenters       string
+	SchemaCompaction        string
+
+	SchemaCmd = &cobra.Command{
+		Use:   "schema",
+		Short: "Create and migrate the graph keyspace",
+		Long: `Create and migrate the graph keyspace.
+
+Migrations are versioned CQL files compiled into dbcli. Applied versions are
+recorded in the schema_migrations table of the configured keyspace.
+Replication and compaction only take effect when the keyspace or a table is
+first created.`,
+	}
+	SchemaInitCmd = &cobra.Command{
+		Use:   "init",
+		Short: "Create the keyspace if needed and apply all migrations",
+		Run: func(cmd *cobra.Command, args []string) {
+			SchemaAction(schema.Init)
+		},
+	}
+	SchemaMigrateCmd = &cobra.Command{
+		Use:   "migrate",
+		Short: "Apply pending migrations to an existing keyspace",
+		Run: func(cmd *cobra.Command, args []string) {
+			SchemaAction(schema.Migrate)
+		},
+	}
+	SchemaStatusCmd = &cobra.Command{
+		Use:   "status",
+		Short: "List migrations and whether they are applied",
+		Run: func(cmd *cobra.Command, args []string) {
+			SchemaStatusAction()
+		},
+	}
+)
+
+func init() {
+	flags := SchemaCmd.PersistentFlags()
+	flags.StringVar(&SchemaReplicationClass, "replication-class", "SimpleStrategy", "SimpleStrategy or NetworkTopologyStrategy")
+	flags.IntVar(&SchemaReplicationFactor, "replication-factor", 1, "Replication factor for SimpleStrategy")
+	flags.StringVar(&SchemaDatacenters, "datacenters", "", "Per-datacenter replication for NetworkTopologyStrategy, e.g. dc1=3,dc2=3")
+	flags.StringVar(&SchemaCompaction, "compaction", "stcs", "Compaction strategy for new tables: stcs, lcs or twcs")
+	SchemaCmd.AddCommand(SchemaInitCmd, SchemaMigrateCmd, SchemaStatusCmd)
+}
+
+func SchemaAction(run func(context.Context, *gocql.Session, schema.Options) ([]schema.Migration, error)) {
+	session, opts := schemaSession()
+	defer session.Close()
+
+	applied, err := run(context.Background(), session, opts)
+	for _, m := range applied {
+		color.Green("✅ Applied %04d_%s", m.Version, m.Name)
+	}
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	if len(applied) == 0 {
+		color.Green("✅ Keyspace %s is up to date", opts.Keyspace)
+	}
+}
+
+func SchemaStatusAction() {
+	session, opts := schemaSession()
+	defer session.Close()
+
+	statuses, err := schema.Statuses(context.Background(), session, opts)
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	color.White("Migrations for keyspace %s:", opts.Keyspace)
+	for _, st := range statuses {
+		name := fmt.Sprintf("%04d_%s", st.Version, st.Name)
+		switch {
+		case st.Modified:
+			color.Red("  %-32s modified after it was applied on %s", name, st.AppliedAt.Format("2006-01-02 15:04:05"))
+		case st.Applied:
+			color.Green("  %-32s applied %s", name, st.AppliedAt.Format("2006-01-02 15:04:05"))
+		default:
+			color.Yellow("  %-32s pending", name)
+		}
+	}
+}
+
+func schemaSession() (*gocql.Session, schema.Options) {
+	datacenters, err := parseDatacenters(SchemaDatacenters)
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	client := cassandraClient()
+	session, err := client.AdminSession()
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	return session, schema.Options{
+		Keyspace:          client.Config().Keyspace,
+		ReplicationClass:  SchemaReplicationClass,
+		ReplicationFactor: SchemaReplicationFactor,
+		Datacenters:       datacenters,
+		Compaction:        SchemaCompaction,
+	}
+}
+
+func parseDatacenters(spec string) (map[string]int, error) {
+	datacenters := make(map[string]int)
+	if spec == "" {
+		return datacenters, nil
+	}
+	for _, entry := range strings.Split(spec, ",") {
+		dc, rf, ok := strings.Cut(entry, "=")
+		factor, err := strconv.Atoi(strings.TrimSpace(rf))
+		if !ok || err != nil {
+			return nil, fmt.Errorf("invalid datacenter replication %q, expected name=factor", entry)
+		}
+		datacenters[strings.TrimSpace(dc)] = factor
+	}
+	return datacenters, nil
+}
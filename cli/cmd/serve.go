@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/DavidZayar/cli/server"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+)
+
+var (
+	ServeAddr           string
+	ServeGRPCAddr       string
+	ServeRequestTimeout time.Duration
+	ServePageSize       int
+	ServeWritable       bool
+
+	ServeCmd = &cobra.Command{
+		Use:   "serve",
+		Short: "Serve the queries as a JSON API over HTTP and gRPC",
+		Long: `Serve the queries over HTTP with JSON responses, and with --grpc-addr as the
+gRPC Graph service (see the graphrpc package). The store is opened once, so
+every request shares the same Cassandra session.
+
+  GET  /nodes/{name}                            labels of a node
+  GET  /nodes/{name}/successors[/count]         outgoing edges (?source=, ?relation=)
+  GET  /nodes/{name}/predecessors[/count]       incoming edges (?source=, ?relation=)
+  GET  /nodes/{name}/neighbors[/count]          neighbours in either direction
+  GET  /nodes/{name}/grandchildren              successors of successors
+  GET  /nodes/{name}/grandparents               predecessors of predecessors
+  GET  /nodes/{name}/synonyms?distance=2        nodes a synonym path reaches
+  GET  /nodes/{name}/antonyms?distance=2        nodes an antonym path reaches
+  POST /nodes/{name}/rename                     {"new_name": "..."}, needs --writable
+  GET  /similar/{name}                          nodes sharing a parent or child
+  GET  /paths?from=&to=                         shortest path
+  GET  /stats                                   node and edge counts
+  GET  /stats/degree                            degree statistics (full scans)
+  GET  /healthz
+
+Node names are path-escaped: /nodes/%2Fc%2Fen%2Fjar/successors. Lists take
+?limit= and ?cursor= and return {"items", "total", "next_cursor"};
+successors, predecessors and neighbours are paged by the store and leave
+out "total". Errors
+are {"error": {"status", "message"}}; a request that runs past
+--request-timeout gets 504.
+
+The gRPC service streams the large results (successors, predecessors,
+similar nodes, breadth-first frontiers and full scans) row by row, and a
+call's deadline or cancellation stops the store read behind it. Unary
+calls without a deadline get --request-timeout; streams run until the
+client stops them.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			ServeAction(cmd.Context())
+		},
+	}
+)
+
+func ServeAction(ctx context.Context) {
+	if ServeAddr == "" && ServeGRPCAddr == "" {
+		log.Fatal("❌ Nothing to serve: give --addr, --grpc-addr or both")
+	}
+	open := openStore
+	if ServeWritable {
+		open = openWritableStore
+	}
+	st := open(ctx)
+	defer st.Close()
+
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGTERM)
+	defer stop()
+
+	opts := server.Options{
+		Timeout:      ServeRequestTimeout,
+		DefaultLimit: ServePageSize,
+		Writable:     ServeWritable,
+	}
+	errs := make(chan error, 2)
+
+	var httpSrv *http.Server
+	if ServeAddr != "" {
+		httpSrv = &http.Server{
+			Addr:              ServeAddr,
+			Handler:           server.Logged(server.New(st, opts)),
+			ReadHeaderTimeout: 10 * time.Second,
+			WriteTimeout:      ServeRequestTimeout + 10*time.Second,
+			IdleTimeout:       2 * time.Minute,
+			BaseContext:       func(net.Listener) context.Context { return context.WithoutCancel(ctx) },
+		}
+		go func() {
+			errs <- httpSrv.ListenAndServe()
+		}()
+		color.Cyan("🌐 Serving %s over HTTP on %s", backendTarget(), ServeAddr)
+	}
+
+	var grpcSrv *grpc.Server
+	if ServeGRPCAddr != "" {
+		lis, err := net.Listen("tcp", ServeGRPCAddr)
+		if err != nil {
+			log.Fatalf("❌ Failed to listen on %s: %v", ServeGRPCAddr, err)
+		}
+		grpcSrv = server.NewGRPC(st, opts)
+		go func() {
+			errs <- grpcSrv.Serve(lis)
+		}()
+		color.Cyan("🌐 Serving %s over gRPC on %s", backendTarget(), ServeGRPCAddr)
+	}
+
+	select {
+	case err := <-errs:
+		log.Fatalf("❌ Failed to serve: %v", err)
+	case <-ctx.Done():
+	}
+
+	color.Yellow("⚠️ Shutting down, waiting for requests in flight")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), ServeRequestTimeout)
+	defer cancel()
+	failed := false
+	if grpcSrv != nil {
+		// GracefulStop waits for streams too, which may be full scans, so
+		// they get the same grace period as HTTP requests.
+		stopped := make(chan struct{})
+		go func() {
+			grpcSrv.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-shutdownCtx.Done():
+			grpcSrv.Stop()
+			failed = true
+		}
+	}
+	if httpSrv != nil {
+		if err := httpSrv.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			failed = true
+		}
+	}
+	if failed {
+		color.Red("❌ Requests were still running after %s and were cut off", ServeRequestTimeout)
+		os.Exit(1)
+	}
+	color.Green("✅ Server stopped")
+}
+
+func init() {
+	ServeCmd.Flags().StringVar(&ServeAddr, "addr", ":8080", "Address to serve HTTP on, empty for none")
+	ServeCmd.Flags().StringVar(&ServeGRPCAddr, "grpc-addr", "", "Address to serve the gRPC Graph service on, empty for none")
+	ServeCmd.Flags().DurationVar(&ServeRequestTimeout, "request-timeout", 30*time.Second, "Longest a request may run before it gets 504")
+	ServeCmd.Flags().IntVar(&ServePageSize, "page-size", 100, "Items per page when a request gives no ?limit=")
+	ServeCmd.Flags().BoolVar(&ServeWritable, "writable", false, "Open the store for writing and allow renames")
+}
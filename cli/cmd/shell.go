@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"time"
+
+	"github.com/DavidZayar/cli/shell"
+	"github.com/chzyer/readline"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	ShellHistory  string
+	ShellWritable bool
+
+	ShellCmd = &cobra.Command{
+		Use:   "shell",
+		Short: "Explore the graph interactively over one connection",
+		Long: `Start an interactive shell that keeps the store open between queries.
+The queries are verbs (one, five, sixteen, ...), results are numbered and
+kept in $_, "let name" keeps them as $name, and a verb that takes a node
+also takes a variable and runs once per node. Tab completes commands,
+variables and node names; history is kept in --history.
+
+Without a terminal the shell reads commands from stdin, one per line, and
+exits with status 1 if any of them failed.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			ShellAction(cmd.Context())
+		},
+	}
+)
+
+func ShellAction(ctx context.Context) {
+	open := openStore
+	if ShellWritable {
+		open = openWritableStore
+	}
+	st := open(ctx)
+	defer st.Close()
+
+	sh := shell.New(st, os.Stdout, ShellWritable)
+	// Ctrl-C stops the running query, not the shell, so each line gets its
+	// own context.
+	base := context.WithoutCancel(ctx)
+	run := func(line string) error {
+		lineCtx, stop := signal.NotifyContext(base, os.Interrupt)
+		defer stop()
+		return sh.Exec(lineCtx, line)
+	}
+
+	if !readline.DefaultIsTerminal() {
+		failed := false
+		scanner := bufio.NewScanner(os.Stdin)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			if err := run(scanner.Text()); errors.Is(err, shell.ErrExit) {
+				break
+			} else if err != nil {
+				color.Red("❌ %v", err)
+				failed = true
+			}
+		}
+		if failed {
+			st.Close()
+			closeCassandra()
+			os.Exit(1)
+		}
+		return
+	}
+
+	rl, err := readline.NewEx(&readline.Config{
+		Prompt:            color.CyanString("dbcli> "),
+		HistoryFile:       ShellHistory,
+		HistorySearchFold: true,
+		AutoComplete:      &shell.Completer{Shell: sh, Timeout: 2 * time.Second, Limit: 50},
+		InterruptPrompt:   "^C",
+		EOFPrompt:         "exit",
+	})
+	if err != nil {
+		log.Fatalf("❌ Failed to start the shell: %v", err)
+	}
+	defer rl.Close()
+
+	color.Cyan("🐚 Connected to %s. Type help for commands, Ctrl-D to leave.", backendTarget())
+	for {
+		line, err := rl.Readline()
+		if errors.Is(err, readline.ErrInterrupt) {
+			continue
+		}
+		if errors.Is(err, io.EOF) {
+			return
+		}
+		if err != nil {
+			log.Fatalf("❌ Failed to read input: %v", err)
+		}
+		if err := run(line); errors.Is(err, shell.ErrExit) {
+			return
+		} else if err != nil {
+			color.Red("❌ %v", err)
+		}
+	}
+}
+
+// defaultHistory keeps the history in the home directory, or nowhere when
+// there is none.
+func defaultHistory() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".dbcli_history")
+}
+
+func init() {
+	ShellCmd.Flags().StringVar(&ShellHistory, "history", defaultHistory(), "File to keep the command history in, empty for none")
+	ShellCmd.Flags().BoolVar(&ShellWritable, "writable", false, "Open the store for writing, so fourteen can rename nodes")
+}
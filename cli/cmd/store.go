@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/DavidZaya21/parser/config"
+	"github.com/DavidZayar/cli/cassandra_client"
+	"github.com/DavidZayar/cli/metrics"
+	"github.com/DavidZayar/cli/store"
+	"github.com/fatih/color"
+	"github.com/gocql/gocql"
+)
+
+// Backends accepted by --backend.
+const (
+	BackendCassandra = "cassandra"
+	BackendLocal     = "local"
+	BackendMemory    = "memory"
+)
+
+var (
+	TSVFile string
+
+	// Config holds the settings shared with the parser, loaded from the
+	// config file, the environment and the global flags before any
+	// command runs.
+	Config      config.Config
+	configFlags = config.NewFlags(config.Defaults())
+)
+
+// cassandra is the one client of the process. Every command that needs the
+// cluster shares its session, and Exec closes it on the way out.
+var (
+	cassandra     *cassandra_client.Client
+	cassandraOnce sync.Once
+)
+
+// openStore opens the graph chosen with --backend for reading. Failing to
+// open it is fatal: no query can run without it. Calls made through the
+// store are counted for --metrics.
+func openStore(ctx context.Context) store.GraphStore {
+	return metrics.Store(openBackend(ctx, false))
+}
+
+// openWritableStore is openStore for commands that change the graph. The
+// local backend only lets one process at a time hold its file writable.
+func openWritableStore(ctx context.Context) store.GraphStore {
+	return metrics.Store(openBackend(ctx, true))
+}
+
+func openBackend(ctx context.Context, writable bool) store.GraphStore {
+	switch Config.Backend {
+	case BackendCassandra:
+		color.Yellow("🔌 Creating the Session")
+		return store.NewCassandra(connectCassandra())
+	case BackendLocal:
+		if Config.DataDir == "" {
+			log.Fatal("❌ --backend=local needs the --data-dir the parser wrote to")
+		}
+		st, err := store.OpenLocal(Config.DataDir, writable)
+		if err != nil {
+			log.Fatalf("❌ Failed to open %s: %v", Config.DataDir, err)
+		}
+		return st
+	case BackendMemory:
+		if TSVFile == "" {
+			log.Fatal("❌ --backend=memory needs a --tsv file to load")
+		}
+		st, err := store.LoadTSV(ctx, TSVFile)
+		if err != nil {
+			log.Fatalf("❌ Failed to load %s: %v", TSVFile, err)
+		}
+		stats, _ := st.Stats(ctx)
+		color.Green("✅ Loaded %s: %d nodes, %d edges", TSVFile, stats.Nodes, stats.Edges)
+		return st
+	default:
+		log.Fatalf("❌ Unknown backend %q, expected %s, %s or %s", Config.Backend, BackendCassandra, BackendLocal, BackendMemory)
+		return nil
+	}
+}
+
+// cassandraClient builds the shared client from Config the first time it is
+// needed.
+func cassandraClient() *cassandra_client.Client {
+	cassandraOnce.Do(func() {
+		cassandra = cassandra_client.New(Config)
+	})
+	return cassandra
+}
+
+// connectCassandra returns the shared session, for the Cassandra backend
+// and for maintenance commands that only make sense there. Callers must not
+// close it.
+func connectCassandra() *gocql.Session {
+	session, err := cassandraClient().Session()
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	return session
+}
+
+// closeCassandra closes the shared session if a command opened one.
+func closeCassandra() {
+	if cassandra != nil {
+		cassandra.Close()
+	}
+}
+
+// backendTarget names the data the chosen backend reads, for reports and
+// banners.
+func backendTarget() string {
+	switch Config.Backend {
+	case BackendLocal:
+		return Config.DataDir
+	case BackendMemory:
+		return TSVFile
+	default:
+		return Config.Target()
+	}
+}
@@ -0,0 +1,218 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/DavidZaya21/parser/model"
+	"github.com/DavidZayar/cli/store"
+)
+
+// Directions an expansion follows edges in.
+const (
+	Out  = "out"
+	In   = "in"
+	Both = "both"
+)
+
+// Defaults of ExpandOptions left at zero.
+const (
+	DefaultExpandConcurrency = 8
+	DefaultExpandBatchSize   = 64
+)
+
+// ExpandOptions controls Expand.
+type ExpandOptions struct {
+	// Hops is how many levels to expand, at least one.
+	Hops int
+	// Direction is Out for successors, In for predecessors or Both.
+	Direction string
+	// Relations filters the edges followed on every hop.
+	Relations store.Relations
+	// MaxFrontier caps the nodes kept at each level, and so the lookups of
+	// the next one. Zero means no cap.
+	MaxFrontier int
+	// Concurrency is how many batches of a level are looked up at once,
+	// and BatchSize how many nodes make a batch.
+	Concurrency int
+	BatchSize   int
+	// Revisit lets a level hold nodes already reached at an earlier one, so
+	// it is every node at the end of a path of exactly Depth hops, the way
+	// grandchildren are counted. The start node is never part of a level.
+	Revisit bool
+}
+
+// Hop is one level of an expansion.
+type Hop struct {
+	Depth int
+	// Nodes are the nodes of the level, sorted, at most MaxFrontier.
+	Nodes []string
+	// Reached counts the nodes of the level before the cap.
+	Reached int
+	// Edges counts the edges read from the previous level.
+	Edges int
+	// Truncated is set when the cap dropped nodes.
+	Truncated bool
+}
+
+// Expand walks the neighbourhood of node one level at a time: every node
+// of a level is looked up before the next level starts, the lookups of a
+// level run concurrently in batches, and each level is deduplicated before
+// it is expanded. Without Revisit a node belongs to the level that first
+// reached it. Expansion stops after opts.Hops levels or at an empty one,
+// which is still returned so every hop has a count.
+func Expand(ctx context.Context, st store.GraphStore, node string, opts ExpandOptions) ([]Hop, error) {
+	opts, err := opts.check()
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{node: true}
+	frontier := []string{node}
+	var hops []Hop
+	for depth := 1; depth <= opts.Hops && len(frontier) > 0; depth++ {
+		hop := Hop{Depth: depth}
+		reached := make(map[string]bool)
+		err := readLevel(ctx, st, frontier, opts, func(from string, edges []model.Edge) {
+			hop.Edges += len(edges)
+			for _, e := range edges {
+				if to := farEnd(e, from); to != node && (opts.Revisit || !seen[to]) {
+					reached[to] = true
+				}
+			}
+		})
+		if err != nil {
+			return hops, err
+		}
+
+		next := make([]string, 0, len(reached))
+		for name := range reached {
+			seen[name] = true
+			next = append(next, name)
+		}
+		slices.Sort(next)
+		hop.Reached = len(next)
+		if opts.MaxFrontier > 0 && len(next) > opts.MaxFrontier {
+			next, hop.Truncated = next[:opts.MaxFrontier], true
+		}
+		hop.Nodes = next
+		hops = append(hops, hop)
+		frontier = next
+	}
+	return hops, nil
+}
+
+func (o ExpandOptions) check() (ExpandOptions, error) {
+	if o.Hops < 1 {
+		return o, fmt.Errorf("hops must be at least 1, got %d", o.Hops)
+	}
+	switch o.Direction {
+	case Out, In, Both:
+	case "":
+		o.Direction = Out
+	default:
+		return o, fmt.Errorf("unknown direction %q, expected %s, %s or %s", o.Direction, Out, In, Both)
+	}
+	if o.MaxFrontier < 0 {
+		return o, fmt.Errorf("max frontier must not be negative, got %d", o.MaxFrontier)
+	}
+	if o.Concurrency <= 0 {
+		o.Concurrency = DefaultExpandConcurrency
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = DefaultExpandBatchSize
+	}
+	return o, nil
+}
+
+// readLevel reads the edges of every node of frontier in opts.Direction,
+// with up to opts.Concurrency workers taking opts.BatchSize nodes at a
+// time. fn gets each node's edges; it is called for a whole batch under
+// one lock, so it needs no locking of its own. The first failed lookup
+// cancels the others and is returned.
+func readLevel(ctx context.Context, st store.GraphStore, frontier []string, opts ExpandOptions, fn func(from string, edges []model.Edge)) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type lookup struct {
+		from  string
+		edges []model.Edge
+	}
+	batches := make(chan []string)
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		errOnce  sync.Once
+		firstErr error
+	)
+	workers := min(opts.Concurrency, (len(frontier)+opts.BatchSize-1)/opts.BatchSize)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range batches {
+				done := make([]lookup, 0, len(batch))
+				for _, from := range batch {
+					edges, err := edgesOf(ctx, st, from, opts)
+					if err != nil {
+						errOnce.Do(func() {
+							firstErr = err
+							cancel()
+						})
+						break
+					}
+					done = append(done, lookup{from, edges})
+				}
+				mu.Lock()
+				for _, l := range done {
+					fn(l.from, l.edges)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+feed:
+	for start := 0; start < len(frontier); start += opts.BatchSize {
+		select {
+		case batches <- frontier[start:min(start+opts.BatchSize, len(frontier))]:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(batches)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
+
+// edgesOf reads the edges of node that an expansion in opts.Direction
+// follows.
+func edgesOf(ctx context.Context, st store.GraphStore, node string, opts ExpandOptions) ([]model.Edge, error) {
+	switch opts.Direction {
+	case In:
+		return predecessors(ctx, st, node, opts.Relations)
+	case Both:
+		succ, err := successors(ctx, st, node, opts.Relations)
+		if err != nil {
+			return nil, err
+		}
+		pred, err := predecessors(ctx, st, node, opts.Relations)
+		return append(succ, pred...), err
+	default:
+		return successors(ctx, st, node, opts.Relations)
+	}
+}
+
+// farEnd returns the end of e that is not from. An edge from a node to
+// itself leads back to it.
+func farEnd(e model.Edge, from string) string {
+	if e.FromNode == from {
+		return e.ToNode
+	}
+	return e.FromNode
+}
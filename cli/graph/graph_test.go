@@ -0,0 +1,438 @@
+package graph
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/DavidZaya21/parser/model"
+	"github.com/DavidZayar/cli/store"
+)
+
+// loadSample loads testdata/sample.tsv, a small graph of vehicles,
+// containers, an antonym chain and two Wikidata items.
+func loadSample(t *testing.T) *store.Memory {
+	t.Helper()
+	st, err := store.LoadTSV(context.Background(), "../testdata/sample.tsv")
+	if err != nil {
+		t.Fatalf("load sample: %v", err)
+	}
+	return st
+}
+
+func relations(t *testing.T, patterns ...string) store.Relations {
+	t.Helper()
+	rel, err := store.ParseRelations(patterns)
+	if err != nil {
+		t.Fatalf("parse relations %q: %v", patterns, err)
+	}
+	return rel
+}
+
+// edgeKeys renders edges as "from relation to", in order, so tables can
+// list them.
+func edgeKeys(edges []model.Edge) []string {
+	keys := make([]string, 0, len(edges))
+	for _, e := range edges {
+		keys = append(keys, e.FromNode+" "+e.RelationType+" "+e.ToNode)
+	}
+	return keys
+}
+
+func sortedEdgeKeys(edges []model.Edge) []string {
+	keys := edgeKeys(edges)
+	slices.Sort(keys)
+	return keys
+}
+
+func TestSuccessorEdges(t *testing.T) {
+	st := loadSample(t)
+	tests := []struct {
+		name      string
+		node      string
+		sources   []string
+		relations []string
+		want      []string
+		unique    int
+	}{
+		{
+			name: "all",
+			node: "/c/en/jar",
+			want: []string{
+				"/c/en/jar /r/AtLocation /c/en/kitchen",
+				"/c/en/jar /r/IsA /c/en/container",
+				"/c/en/jar /r/RelatedTo /c/en/jar",
+			},
+			unique: 3,
+		},
+		{
+			name:    "source",
+			node:    "/c/en/jar",
+			sources: []string{"WN"},
+			want:    []string{"/c/en/jar /r/IsA /c/en/container"},
+			unique:  1,
+		},
+		{
+			name:      "relation",
+			node:      "/c/en/jar",
+			relations: []string{"/r/IsA"},
+			want:      []string{"/c/en/jar /r/IsA /c/en/container"},
+			unique:    1,
+		},
+		{
+			name:      "excluded relation",
+			node:      "/c/en/jar",
+			relations: []string{"-/r/RelatedTo"},
+			want: []string{
+				"/c/en/jar /r/AtLocation /c/en/kitchen",
+				"/c/en/jar /r/IsA /c/en/container",
+			},
+			unique: 2,
+		},
+		{
+			name:    "joined sources",
+			node:    "/c/en/car",
+			sources: []string{"WN"},
+			want:    []string{"/c/en/car /r/IsA /c/en/vehicle"},
+			unique:  1,
+		},
+		{
+			name: "no successors",
+			node: "/c/en/kitchen",
+		},
+		{
+			name: "unknown node",
+			node: "/c/en/nowhere",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edges, unique, err := SuccessorEdges(context.Background(), st, tt.node, tt.sources, relations(t, tt.relations...))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := sortedEdgeKeys(edges); !slices.Equal(got, tt.want) {
+				t.Errorf("edges = %q, want %q", got, tt.want)
+			}
+			if unique != tt.unique {
+				t.Errorf("unique = %d, want %d", unique, tt.unique)
+			}
+		})
+	}
+}
+
+func TestPredecessors(t *testing.T) {
+	st := loadSample(t)
+	tests := []struct {
+		name      string
+		node      string
+		sources   []string
+		relations []string
+		want      []string
+		labels    [][]string
+	}{
+		{
+			name:   "all",
+			node:   "/c/en/vehicle",
+			want:   []string{"/c/en/car", "/c/en/locomotive"},
+			labels: [][]string{{"car", "automobile"}, {"locomotive"}},
+		},
+		{
+			name:    "source",
+			node:    "/c/en/vehicle",
+			sources: []string{"WN"},
+			want:    []string{"/c/en/car"},
+			labels:  [][]string{{"car", "automobile"}},
+		},
+		{
+			name:      "relation",
+			node:      "Q40157",
+			relations: []string{"P279"},
+			want:      []string{"Q42278"},
+			labels:    [][]string{{"magma"}},
+		},
+		{
+			name:   "self-loop",
+			node:   "/c/en/jar",
+			want:   []string{"/c/en/jar"},
+			labels: [][]string{{"jar"}},
+		},
+		{
+			name:      "relation without edges",
+			node:      "/c/en/vehicle",
+			relations: []string{"/r/UsedFor"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			preds, err := Predecessors(context.Background(), st, tt.node, tt.sources, relations(t, tt.relations...))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			var labels [][]string
+			for _, p := range preds {
+				got = append(got, p.Node)
+				labels = append(labels, p.Labels)
+				for _, e := range p.Edges {
+					if e.FromNode != p.Node || e.ToNode != tt.node {
+						t.Errorf("%s has edge %s -> %s", p.Node, e.FromNode, e.ToNode)
+					}
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("predecessors = %q, want %q", got, tt.want)
+			}
+			if !slices.EqualFunc(labels, tt.labels, slices.Equal) {
+				t.Errorf("labels = %q, want %q", labels, tt.labels)
+			}
+		})
+	}
+}
+
+func TestNeighbors(t *testing.T) {
+	st := loadSample(t)
+	tests := []struct {
+		name      string
+		node      string
+		relations []string
+		want      []string
+		skipped   int
+	}{
+		{
+			name: "both directions",
+			node: "/c/en/vehicle",
+			want: []string{"/c/en/car", "/c/en/locomotive", "/c/en/transportation"},
+		},
+		{
+			name:      "relation",
+			node:      "/c/en/vehicle",
+			relations: []string{"/r/IsA"},
+			want:      []string{"/c/en/car", "/c/en/locomotive"},
+		},
+		{
+			name:    "self-loop skipped both ways",
+			node:    "/c/en/jar",
+			want:    []string{"/c/en/container", "/c/en/kitchen"},
+			skipped: 2,
+		},
+		{
+			name:      "self-loop filtered out",
+			node:      "/c/en/jar",
+			relations: []string{"-/r/RelatedTo"},
+			want:      []string{"/c/en/container", "/c/en/kitchen"},
+		},
+		{
+			name: "unknown node",
+			node: "/c/en/nowhere",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, skipped, err := Neighbors(context.Background(), st, tt.node, relations(t, tt.relations...))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("neighbors = %q, want %q", got, tt.want)
+			}
+			if skipped != tt.skipped {
+				t.Errorf("skipped = %d, want %d", skipped, tt.skipped)
+			}
+		})
+	}
+}
+
+func TestGrandchildren(t *testing.T) {
+	st := loadSample(t)
+	tests := []struct {
+		name      string
+		node      string
+		relations []string
+		want      []string
+	}{
+		{name: "two hops", node: "/c/en/steam_locomotive", want: []string{"/c/en/vehicle"}},
+		{name: "mixed relations", node: "/c/en/locomotive", want: []string{"/c/en/transportation"}},
+		{name: "relation on both hops", node: "/c/en/locomotive", relations: []string{"/r/IsA"}},
+		{name: "antonym chain", node: "/c/en/defeatable", want: []string{"/c/en/surmountable"}},
+		{name: "own self-loop is not a hop", node: "/c/en/jar"},
+		{name: "leaf", node: "/c/en/transportation"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := Grandchildren(context.Background(), st, tt.node, relations(t, tt.relations...))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("grandchildren = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGrandparents(t *testing.T) {
+	st := loadSample(t)
+	tests := []struct {
+		name      string
+		node      string
+		relations []string
+		want      []string
+	}{
+		{name: "two hops", node: "/c/en/transportation", want: []string{"/c/en/car", "/c/en/locomotive"}},
+		{name: "relation on both hops", node: "/c/en/transportation", relations: []string{"/r/IsA"}},
+		{name: "through a self-loop", node: "/c/en/container", want: []string{"/c/en/jar"}},
+		{name: "one hop only", node: "/c/en/locomotive"},
+		{name: "antonym chain", node: "/c/en/unconquerable", want: []string{"/c/en/surmountable"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Grandparents(context.Background(), st, tt.node, relations(t, tt.relations...))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("grandparents = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShortestPath(t *testing.T) {
+	st := loadSample(t)
+	tests := []struct {
+		name   string
+		from   string
+		to     string
+		want   []string
+		length int
+	}{
+		{
+			name:   "same node",
+			from:   "/c/en/jar",
+			to:     "/c/en/jar",
+			want:   []string{"/c/en/jar"},
+			length: 0,
+		},
+		{
+			name:   "along the edges",
+			from:   "/c/en/steam_locomotive",
+			to:     "/c/en/transportation",
+			want:   []string{"/c/en/steam_locomotive", "/c/en/locomotive", "/c/en/vehicle", "/c/en/transportation"},
+			length: 3,
+		},
+		{
+			name:   "against the edges",
+			from:   "/c/en/unconquerable",
+			to:     "/c/en/defeatable",
+			want:   []string{"/c/en/unconquerable", "/c/en/conquerable", "/c/en/surmountable", "/c/en/insurmountable", "/c/en/defeatable"},
+			length: 4,
+		},
+		{
+			name:   "through a shared target",
+			from:   "Q42278",
+			to:     "Q119306",
+			want:   []string{"Q42278", "Q40157", "Q119306"},
+			length: 2,
+		},
+		{
+			name:   "unreachable",
+			from:   "/c/en/jar",
+			to:     "/c/en/vehicle",
+			length: -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, length, visited, err := ShortestPath(context.Background(), st, tt.from, tt.to)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(path, tt.want) {
+				t.Errorf("path = %q, want %q", path, tt.want)
+			}
+			if length != tt.length {
+				t.Errorf("length = %d, want %d", length, tt.length)
+			}
+			if visited < len(path) {
+				t.Errorf("visited = %d, fewer than the %d nodes on the path", visited, len(path))
+			}
+		})
+	}
+}
+
+func TestScans(t *testing.T) {
+	st := loadSample(t)
+	ctx := context.Background()
+	tests := []struct {
+		name string
+		scan func() (int, error)
+		want int
+	}{
+		{name: "nodes", scan: func() (int, error) { return CountNodes(ctx, st) }, want: 17},
+		{name: "without successors", scan: func() (int, error) { return CountWithoutSuccessors(ctx, st) }, want: 5},
+		{name: "without predecessors", scan: func() (int, error) { return CountWithoutPredecessors(ctx, st) }, want: 6},
+		{name: "single neighbor", scan: func() (int, error) { return CountSingleNeighbor(ctx, st) }, want: 9},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.scan()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("most neighbors", func(t *testing.T) {
+		count, nodes, err := MostNeighbors(ctx, st)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := []string{"/c/en/vehicle"}; count != 3 || !slices.Equal(nodes, want) {
+			t.Errorf("got %d %q, want 3 %q", count, nodes, want)
+		}
+	})
+}
+
+// TestSelfLoopScans checks how the neighbour scans treat a self-loop:
+// query twelve ignores it, query thirteen counts the node as its own
+// neighbour.
+func TestSelfLoopScans(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name   string
+		edges  [][2]string
+		single int
+		most   int
+		nodes  []string
+	}{
+		{name: "loop only", edges: [][2]string{{"a", "a"}}, single: 1},
+		{name: "loop and one neighbour", edges: [][2]string{{"x", "x"}, {"x", "y"}}, single: 1, most: 1, nodes: []string{"x", "y"}},
+		{name: "no loop", edges: [][2]string{{"x", "y"}}, single: 2, most: 1, nodes: []string{"x", "y"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := store.NewMemory()
+			for _, e := range tt.edges {
+				st.AddEdge(model.Edge{FromNode: e[0], ToNode: e[1], RelationType: "/r/RelatedTo"})
+			}
+			single, err := CountSingleNeighbor(ctx, st)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if single != tt.single {
+				t.Errorf("single neighbour = %d, want %d", single, tt.single)
+			}
+			most, nodes, err := MostNeighbors(ctx, st)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if most != tt.most || !slices.Equal(nodes, tt.nodes) {
+				t.Errorf("most neighbours = %d %q, want %d %q", most, nodes, tt.most, tt.nodes)
+			}
+		})
+	}
+}
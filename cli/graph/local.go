@@ -0,0 +1,278 @@
+// Package graph holds the logic of the dbcli queries. Every function works
+// against a store.GraphStore and returns plain results, leaving printing and
+// timing to the commands, so the same query runs on any backend.
+package graph
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/DavidZaya21/parser/model"
+	"github.com/DavidZayar/cli/store"
+)
+
+// SuccessorEdges returns the outgoing edges of node that pass the source
+// and relation filters, along with how many distinct successors they lead
+// to.
+func SuccessorEdges(ctx context.Context, st store.GraphStore, node string, sources []string, rel store.Relations) ([]model.Edge, int, error) {
+	all, err := successors(ctx, st, node, rel)
+	if err != nil {
+		return nil, 0, err
+	}
+	var edges []model.Edge
+	unique := make(map[string]bool)
+	for _, e := range all {
+		if !MatchesSource(e.Source, sources) {
+			continue
+		}
+		edges = append(edges, e)
+		unique[e.ToNode] = true
+	}
+	return edges, len(unique), nil
+}
+
+// CountSuccessors counts the distinct successors of node. Edges back to node
+// itself, compared case-insensitively, are counted as skipped instead.
+func CountSuccessors(ctx context.Context, st store.GraphStore, node string, rel store.Relations) (count, skipped int, err error) {
+	edges, err := successors(ctx, st, node, rel)
+	if err != nil {
+		return 0, 0, err
+	}
+	unique := make(map[string]bool)
+	for _, e := range edges {
+		if strings.EqualFold(e.ToNode, node) {
+			skipped++
+			continue
+		}
+		unique[e.ToNode] = true
+	}
+	return len(unique), skipped, nil
+}
+
+// Predecessor is one node with edges into the queried node.
+type Predecessor struct {
+	Node   string
+	Labels []string
+	Edges  []model.Edge
+}
+
+// Predecessors returns the nodes with an edge into node that passes the
+// source and relation filters, each with its labels and those edges, sorted
+// by name.
+func Predecessors(ctx context.Context, st store.GraphStore, node string, sources []string, rel store.Relations) ([]Predecessor, error) {
+	edges, err := predecessors(ctx, st, node, rel)
+	if err != nil {
+		return nil, err
+	}
+	return groupPredecessors(ctx, st, edges, sources)
+}
+
+// groupPredecessors groups incoming edges that pass the source filter by
+// the node they come from and looks up its labels.
+func groupPredecessors(ctx context.Context, st store.GraphStore, edges []model.Edge, sources []string) ([]Predecessor, error) {
+	byNode := make(map[string][]model.Edge)
+	for _, e := range edges {
+		if MatchesSource(e.Source, sources) {
+			byNode[e.FromNode] = append(byNode[e.FromNode], e)
+		}
+	}
+
+	preds := make([]Predecessor, 0, len(byNode))
+	for _, name := range sortedKeys(byNode) {
+		labels, err := st.Labels(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		preds = append(preds, Predecessor{Node: name, Labels: labels, Edges: byNode[name]})
+	}
+	return preds, nil
+}
+
+// CountPredecessors counts the distinct predecessors of node. It reads the
+// edges as they come and keeps only the names.
+func CountPredecessors(ctx context.Context, st store.GraphStore, node string, rel store.Relations) (int, error) {
+	unique := make(map[string]bool)
+	err := st.EachPredecessor(ctx, node, rel, func(e model.Edge) error {
+		unique[e.FromNode] = true
+		return nil
+	})
+	return len(unique), err
+}
+
+// Neighbors returns the sorted successors and predecessors of node. Edges
+// from node to itself are counted as skipped.
+func Neighbors(ctx context.Context, st store.GraphStore, node string, rel store.Relations) (neighbors []string, skipped int, err error) {
+	succ, err := successors(ctx, st, node, rel)
+	if err != nil {
+		return nil, 0, err
+	}
+	pred, err := predecessors(ctx, st, node, rel)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	unique := make(map[string]bool)
+	for _, e := range succ {
+		if e.ToNode == node {
+			skipped++
+			continue
+		}
+		unique[e.ToNode] = true
+	}
+	for _, e := range pred {
+		if e.FromNode == node {
+			skipped++
+			continue
+		}
+		unique[e.FromNode] = true
+	}
+	return sortedKeys(unique), skipped, nil
+}
+
+// Grandchildren returns the sorted successors of the successors of node,
+// leaving out node itself: a two-hop Expand that lets a child also be a
+// grandchild. Skipped counts edges back to node and repeated grandchildren.
+// The relation filter applies to both hops.
+func Grandchildren(ctx context.Context, st store.GraphStore, node string, rel store.Relations) (grandchildren []string, skipped int, err error) {
+	hops, err := Expand(ctx, st, node, ExpandOptions{Hops: 2, Direction: Out, Relations: rel, Revisit: true})
+	if err != nil || len(hops) < 2 {
+		return nil, 0, err
+	}
+	return hops[1].Nodes, hops[1].Edges - len(hops[1].Nodes), nil
+}
+
+// Grandparents returns the sorted predecessors of the predecessors of node,
+// leaving out node itself, as Grandchildren does going the other way.
+func Grandparents(ctx context.Context, st store.GraphStore, node string, rel store.Relations) ([]string, error) {
+	hops, err := Expand(ctx, st, node, ExpandOptions{Hops: 2, Direction: In, Relations: rel, Revisit: true})
+	if err != nil || len(hops) < 2 {
+		return nil, err
+	}
+	return hops[1].Nodes, nil
+}
+
+// secondHopEdges returns the edges that lead from the first level of a
+// two-hop expansion of node to the second, for grouping them by relation.
+func secondHopEdges(ctx context.Context, st store.GraphStore, node, direction string, rel store.Relations) ([]model.Edge, error) {
+	opts, err := ExpandOptions{Hops: 1, Direction: direction, Relations: rel}.check()
+	if err != nil {
+		return nil, err
+	}
+	hops, err := Expand(ctx, st, node, opts)
+	if err != nil {
+		return nil, err
+	}
+	var edges []model.Edge
+	err = readLevel(ctx, st, hops[0].Nodes, opts, func(_ string, found []model.Edge) {
+		edges = append(edges, found...)
+	})
+	return edges, err
+}
+
+// Similar returns the nodes that share a parent or a child with node over
+// the same relation, sorted.
+func Similar(ctx context.Context, st store.GraphStore, node string) ([]string, error) {
+	similar := make(map[string]bool)
+	err := EachSimilar(ctx, st, node, func(name string) error {
+		similar[name] = true
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sortedKeys(similar), nil
+}
+
+// EachSimilar calls fn once for each node Similar returns, as soon as it is
+// found rather than sorted. Returning an error from fn stops the search and
+// is passed through.
+func EachSimilar(ctx context.Context, st store.GraphStore, node string, fn func(name string) error) error {
+	seen := make(map[string]bool)
+	emit := func(name string) error {
+		if name == node || seen[name] {
+			return nil
+		}
+		seen[name] = true
+		return fn(name)
+	}
+
+	parents, err := st.Predecessors(ctx, node)
+	if err != nil {
+		return err
+	}
+	for _, p := range parents {
+		siblings, err := st.Successors(ctx, p.FromNode)
+		if err != nil {
+			return err
+		}
+		for _, s := range siblings {
+			if s.RelationType == p.RelationType {
+				if err := emit(s.ToNode); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	children, err := st.Successors(ctx, node)
+	if err != nil {
+		return err
+	}
+	for _, c := range children {
+		coParents, err := st.Predecessors(ctx, c.ToNode)
+		if err != nil {
+			return err
+		}
+		for _, s := range coParents {
+			if s.RelationType == c.RelationType {
+				if err := emit(s.FromNode); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// successors reads the outgoing edges of node, through the store's relation
+// filter when there is one.
+func successors(ctx context.Context, st store.GraphStore, node string, rel store.Relations) ([]model.Edge, error) {
+	if rel.All() {
+		return st.Successors(ctx, node)
+	}
+	return st.SuccessorsByRelation(ctx, node, rel)
+}
+
+func predecessors(ctx context.Context, st store.GraphStore, node string, rel store.Relations) ([]model.Edge, error) {
+	if rel.All() {
+		return st.Predecessors(ctx, node)
+	}
+	return st.PredecessorsByRelation(ctx, node, rel)
+}
+
+// MatchesSource reports whether an edge with the given source passes a
+// --source filter. CSKG joins multiple sources with '|', so any one of them
+// matching is enough. An empty filter matches everything.
+func MatchesSource(source string, filter []string) bool {
+	if len(filter) == 0 {
+		return true
+	}
+	for _, s := range strings.Split(source, "|") {
+		for _, f := range filter {
+			if strings.EqualFold(strings.TrimSpace(s), f) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,44 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/DavidZaya21/parser/model"
+	"github.com/DavidZayar/cli/store"
+)
+
+// SuccessorEdgesPage is SuccessorEdges over one page of the outgoing edges.
+// The source and relation filters apply within the page, so a filtered
+// page can come back with fewer edges than asked for, or none, and still
+// have a next one.
+func SuccessorEdgesPage(ctx context.Context, st store.GraphStore, node string, sources []string, rel store.Relations, p store.Page) ([]model.Edge, string, error) {
+	all, next, err := st.SuccessorsPage(ctx, node, p)
+	if err != nil {
+		return nil, "", err
+	}
+	var edges []model.Edge
+	for _, e := range all {
+		if MatchesSource(e.Source, sources) && rel.Match(e.RelationType) {
+			edges = append(edges, e)
+		}
+	}
+	return edges, next, nil
+}
+
+// PredecessorsPage is Predecessors over one page of the incoming edges. A
+// node whose edges fall on two pages is listed on both, with the edges of
+// each.
+func PredecessorsPage(ctx context.Context, st store.GraphStore, node string, sources []string, rel store.Relations, p store.Page) ([]Predecessor, string, error) {
+	edges, next, err := st.PredecessorsPage(ctx, node, p)
+	if err != nil {
+		return nil, "", err
+	}
+	var kept []model.Edge
+	for _, e := range edges {
+		if rel.Match(e.RelationType) {
+			kept = append(kept, e)
+		}
+	}
+	preds, err := groupPredecessors(ctx, st, kept, sources)
+	return preds, next, err
+}
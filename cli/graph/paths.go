@@ -0,0 +1,214 @@
+package graph
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/DavidZayar/cli/store"
+)
+
+// Relations followed by the distant synonym and antonym queries.
+const (
+	Synonym = "synonym"
+	Antonym = "antonym"
+)
+
+// PathResult is a node reached by a path query and the path that reached it.
+type PathResult struct {
+	Node string
+	Path []string
+}
+
+type pathNode struct {
+	Node     string
+	Distance int
+	Path     []string
+}
+
+// ShortestPath runs a breadth-first search over the undirected neighbours of
+// each node. It returns the path, its length and how many nodes were
+// visited; a nil path with distance -1 means to cannot be reached.
+func ShortestPath(ctx context.Context, st store.GraphStore, from, to string) ([]string, int, int, error) {
+	if from == to {
+		return []string{from}, 0, 1, nil
+	}
+
+	visited := map[string]bool{from: true}
+	queue := []pathNode{{Node: from, Distance: 0, Path: []string{from}}}
+	nodesVisited := 1
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		neighbors, err := st.Neighbors(ctx, current.Node)
+		if err != nil {
+			return nil, -1, nodesVisited, err
+		}
+		for _, neighbor := range neighbors {
+			if neighbor == to {
+				return append(slices.Clone(current.Path), neighbor), current.Distance + 1, nodesVisited + 1, nil
+			}
+			if !visited[neighbor] {
+				visited[neighbor] = true
+				nodesVisited++
+				queue = append(queue, pathNode{
+					Node:     neighbor,
+					Distance: current.Distance + 1,
+					Path:     append(slices.Clone(current.Path), neighbor),
+				})
+			}
+		}
+	}
+	return nil, -1, nodesVisited, nil
+}
+
+// EachFrontier runs the breadth-first search of ShortestPath from node
+// without a target and calls fn with each level: the nodes first reached
+// after depth hops, sorted. It stops after maxDepth levels, at the first
+// empty level, or when fn returns an error, which is passed through.
+func EachFrontier(ctx context.Context, st store.GraphStore, node string, maxDepth int, fn func(depth int, frontier []string) error) error {
+	visited := map[string]bool{node: true}
+	frontier := []string{node}
+	for depth := 1; depth <= maxDepth && len(frontier) > 0; depth++ {
+		var next []string
+		for _, current := range frontier {
+			neighbors, err := st.Neighbors(ctx, current)
+			if err != nil {
+				return err
+			}
+			for _, neighbor := range neighbors {
+				if !visited[neighbor] {
+					visited[neighbor] = true
+					next = append(next, neighbor)
+				}
+			}
+		}
+		if len(next) == 0 {
+			return nil
+		}
+		slices.Sort(next)
+		if err := fn(depth, next); err != nil {
+			return err
+		}
+		frontier = next
+	}
+	return nil
+}
+
+// DistantSynonyms returns the nodes exactly distance synonym or antonym
+// edges away from node that work out as synonyms: two antonyms cancel out.
+func DistantSynonyms(ctx context.Context, st store.GraphStore, node string, distance int) ([]PathResult, error) {
+	return distantRelatives(ctx, st, node, distance, Synonym)
+}
+
+// DistantAntonyms is DistantSynonyms for nodes that work out as antonyms.
+func DistantAntonyms(ctx context.Context, st store.GraphStore, node string, distance int) ([]PathResult, error) {
+	return distantRelatives(ctx, st, node, distance, Antonym)
+}
+
+// distantRelatives walks synonym and antonym edges in both directions from
+// node, tracking whether the path so far amounts to a synonym or an antonym,
+// and keeps the nodes at the target distance that amount to want. A node is
+// only queued once per distance and never twice on one path.
+func distantRelatives(ctx context.Context, st store.GraphStore, node string, target int, want string) ([]PathResult, error) {
+	type queueItem struct {
+		Node         string
+		Distance     int
+		Path         []string
+		RelationType string
+	}
+
+	var results []PathResult
+	visited := make(map[string]map[int]bool) // node -> distance -> visited
+	queue := []queueItem{{Node: node, Distance: 0, Path: []string{node}, RelationType: Synonym}}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if current.Distance == target {
+			if current.RelationType == want {
+				results = append(results, PathResult{Node: current.Node, Path: current.Path})
+			}
+			continue
+		}
+		if current.Distance > target {
+			continue
+		}
+
+		synonyms, antonyms, err := synonymAntonymNeighbors(ctx, st, current.Node)
+		if err != nil {
+			return nil, err
+		}
+		next := current.Distance + 1
+		follow := func(neighbor, relationType string) {
+			if visited[neighbor][next] || slices.Contains(current.Path, neighbor) {
+				return
+			}
+			if visited[neighbor] == nil {
+				visited[neighbor] = make(map[int]bool)
+			}
+			visited[neighbor][next] = true
+			queue = append(queue, queueItem{
+				Node:         neighbor,
+				Distance:     next,
+				Path:         append(slices.Clone(current.Path), neighbor),
+				RelationType: relationType,
+			})
+		}
+
+		// Synonym of synonym = synonym, synonym of antonym = antonym
+		for _, neighbor := range synonyms {
+			follow(neighbor, current.RelationType)
+		}
+		// Antonym flips the relation type
+		for _, neighbor := range antonyms {
+			follow(neighbor, flipRelation(current.RelationType))
+		}
+	}
+	return results, nil
+}
+
+// synonymAntonymNeighbors returns the nodes joined to node by a synonym or
+// antonym edge, treating those relations as undirected.
+func synonymAntonymNeighbors(ctx context.Context, st store.GraphStore, node string) (synonyms, antonyms []string, err error) {
+	pred, err := st.Predecessors(ctx, node)
+	if err != nil {
+		return nil, nil, err
+	}
+	succ, err := st.Successors(ctx, node)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	add := func(relation, other string) {
+		switch relationName(relation) {
+		case Synonym:
+			synonyms = append(synonyms, other)
+		case Antonym:
+			antonyms = append(antonyms, other)
+		}
+	}
+	for _, e := range pred {
+		add(e.RelationType, e.FromNode)
+	}
+	for _, e := range succ {
+		add(e.RelationType, e.ToNode)
+	}
+	return synonyms, antonyms, nil
+}
+
+// relationName reduces a relation to its lower-case name, so that both the
+// bare "synonym" and CSKG's "/r/Synonym" count as synonyms.
+func relationName(relation string) string {
+	return strings.ToLower(relation[strings.LastIndex(relation, "/")+1:])
+}
+
+func flipRelation(current string) string {
+	if current == Synonym {
+		return Antonym
+	}
+	return Synonym
+}
@@ -0,0 +1,121 @@
+package graph
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/DavidZaya21/parser/model"
+	"github.com/DavidZayar/cli/store"
+)
+
+// RelationGroup is the distinct nodes reached over one relation type,
+// sorted. A node joined by several relations is in the group of each.
+type RelationGroup struct {
+	Relation string
+	Nodes    []string
+}
+
+// SuccessorsByRelation groups the successors of node by the relation of
+// the edge leading to them, as --group-by=relation shows query one. Like
+// SuccessorEdges it keeps edges back to node itself.
+func SuccessorsByRelation(ctx context.Context, st store.GraphStore, node string, sources []string, rel store.Relations) ([]RelationGroup, error) {
+	return successorGroups(ctx, st, node, sources, rel, false)
+}
+
+// CountSuccessorsByRelation groups the successors of node by relation for
+// query two. Edges back to node itself, compared case-insensitively, are
+// left out as CountSuccessors skips them.
+func CountSuccessorsByRelation(ctx context.Context, st store.GraphStore, node string, rel store.Relations) ([]RelationGroup, error) {
+	return successorGroups(ctx, st, node, nil, rel, true)
+}
+
+func successorGroups(ctx context.Context, st store.GraphStore, node string, sources []string, rel store.Relations, skipSelf bool) ([]RelationGroup, error) {
+	edges, err := successors(ctx, st, node, rel)
+	if err != nil {
+		return nil, err
+	}
+	return groupByRelation(edges, func(e model.Edge) string {
+		if !MatchesSource(e.Source, sources) || (skipSelf && strings.EqualFold(e.ToNode, node)) {
+			return ""
+		}
+		return e.ToNode
+	}), nil
+}
+
+// PredecessorsByRelation groups the predecessors of node by relation,
+// queries three and four.
+func PredecessorsByRelation(ctx context.Context, st store.GraphStore, node string, sources []string, rel store.Relations) ([]RelationGroup, error) {
+	edges, err := predecessors(ctx, st, node, rel)
+	if err != nil {
+		return nil, err
+	}
+	return groupByRelation(edges, func(e model.Edge) string {
+		if !MatchesSource(e.Source, sources) {
+			return ""
+		}
+		return e.FromNode
+	}), nil
+}
+
+// NeighborsByRelation groups the neighbours of node in either direction by
+// the relation of the edge joining them, queries five and six. Edges from
+// node to itself are left out.
+func NeighborsByRelation(ctx context.Context, st store.GraphStore, node string, rel store.Relations) ([]RelationGroup, error) {
+	succ, err := successors(ctx, st, node, rel)
+	if err != nil {
+		return nil, err
+	}
+	pred, err := predecessors(ctx, st, node, rel)
+	if err != nil {
+		return nil, err
+	}
+	return groupByRelation(append(succ, pred...), func(e model.Edge) string {
+		if e.FromNode == node {
+			return e.ToNode
+		}
+		return e.FromNode
+	}, node), nil
+}
+
+// GrandchildrenByRelation groups the grandchildren of node by the relation
+// of the second hop, the edge that reaches them, query seven.
+func GrandchildrenByRelation(ctx context.Context, st store.GraphStore, node string, rel store.Relations) ([]RelationGroup, error) {
+	edges, err := secondHopEdges(ctx, st, node, Out, rel)
+	if err != nil {
+		return nil, err
+	}
+	return groupByRelation(edges, func(e model.Edge) string { return e.ToNode }, node), nil
+}
+
+// GrandparentsByRelation groups the grandparents of node by the relation of
+// the second hop, query eight.
+func GrandparentsByRelation(ctx context.Context, st store.GraphStore, node string, rel store.Relations) ([]RelationGroup, error) {
+	edges, err := secondHopEdges(ctx, st, node, In, rel)
+	if err != nil {
+		return nil, err
+	}
+	return groupByRelation(edges, func(e model.Edge) string { return e.FromNode }, node), nil
+}
+
+// groupByRelation puts other(e) of every edge in the group of its relation,
+// once each, leaving out "" and the names in skip. Groups are sorted by
+// relation.
+func groupByRelation(edges []model.Edge, other func(model.Edge) string, skip ...string) []RelationGroup {
+	byRelation := make(map[string]map[string]bool)
+	for _, e := range edges {
+		name := other(e)
+		if name == "" || slices.Contains(skip, name) {
+			continue
+		}
+		if byRelation[e.RelationType] == nil {
+			byRelation[e.RelationType] = make(map[string]bool)
+		}
+		byRelation[e.RelationType][name] = true
+	}
+	groups := make([]RelationGroup, 0, len(byRelation))
+	for _, relation := range sortedKeys(byRelation) {
+		groups = append(groups, RelationGroup{Relation: relation, Nodes: sortedKeys(byRelation[relation])})
+	}
+	return groups
+}
@@ -0,0 +1,65 @@
+package graph
+
+import (
+	"context"
+	"testing"
+)
+
+func groupTotal(groups []RelationGroup) int {
+	total := 0
+	for _, g := range groups {
+		total += len(g.Nodes)
+	}
+	return total
+}
+
+// TestGroupedTotals checks that --group-by=relation lists the same
+// successors as the plain queries one and two. No node of the sample is
+// reached over two relations, so the group sizes add up to the counts.
+func TestGroupedTotals(t *testing.T) {
+	st := loadSample(t)
+	ctx := context.Background()
+	tests := []struct {
+		name      string
+		node      string
+		relations []string
+		groups    int
+	}{
+		{name: "self-loop", node: "/c/en/jar", groups: 3},
+		{name: "self-loop filtered out", node: "/c/en/jar", relations: []string{"-/r/RelatedTo"}, groups: 2},
+		{name: "one relation", node: "/c/en/vehicle", groups: 1},
+		{name: "leaf", node: "/c/en/kitchen"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rel := relations(t, tt.relations...)
+
+			_, unique, err := SuccessorEdges(ctx, st, tt.node, nil, rel)
+			if err != nil {
+				t.Fatal(err)
+			}
+			groups, err := SuccessorsByRelation(ctx, st, tt.node, nil, rel)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(groups) != tt.groups {
+				t.Errorf("query one: %d groups, want %d", len(groups), tt.groups)
+			}
+			if total := groupTotal(groups); total != unique {
+				t.Errorf("query one: groups hold %d successors, ungrouped %d", total, unique)
+			}
+
+			count, _, err := CountSuccessors(ctx, st, tt.node, rel)
+			if err != nil {
+				t.Fatal(err)
+			}
+			counted, err := CountSuccessorsByRelation(ctx, st, tt.node, rel)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if total := groupTotal(counted); total != count {
+				t.Errorf("query two: groups hold %d successors, ungrouped %d", total, count)
+			}
+		})
+	}
+}
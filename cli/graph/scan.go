@@ -0,0 +1,106 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/DavidZaya21/parser/model"
+	"github.com/DavidZayar/cli/store"
+)
+
+// The queries in this file read the whole graph. On Cassandra they are full
+// table scans and take as long as the data set is large.
+
+// CountNodes counts every node in the store.
+func CountNodes(ctx context.Context, st store.GraphStore) (int, error) {
+	count := 0
+	err := st.Nodes(ctx, func(string) error {
+		count++
+		return nil
+	})
+	return count, err
+}
+
+// CountWithoutSuccessors counts the nodes that no edge starts from.
+func CountWithoutSuccessors(ctx context.Context, st store.GraphStore) (int, error) {
+	return countUntouched(ctx, st, func(e model.Edge) string { return e.FromNode })
+}
+
+// CountWithoutPredecessors counts the nodes that no edge points to.
+func CountWithoutPredecessors(ctx context.Context, st store.GraphStore) (int, error) {
+	return countUntouched(ctx, st, func(e model.Edge) string { return e.ToNode })
+}
+
+// countUntouched counts the nodes that end does not return for any edge.
+func countUntouched(ctx context.Context, st store.GraphStore, end func(model.Edge) string) (int, error) {
+	nodes := make(map[string]bool)
+	err := st.Nodes(ctx, func(name string) error {
+		nodes[name] = true
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	err = st.Edges(ctx, func(e model.Edge) error {
+		delete(nodes, end(e))
+		return nil
+	})
+	return len(nodes), err
+}
+
+// MostNeighbors returns the highest number of distinct neighbours any node
+// has, and the sorted nodes that have that many. A self-loop does not make
+// a node its own neighbour.
+func MostNeighbors(ctx context.Context, st store.GraphStore) (int, []string, error) {
+	neighbors, err := neighborSets(ctx, st, false)
+	if err != nil {
+		return 0, nil, err
+	}
+	maxCount := 0
+	for _, set := range neighbors {
+		maxCount = max(maxCount, len(set))
+	}
+	var most []string
+	for _, node := range sortedKeys(neighbors) {
+		if len(neighbors[node]) == maxCount {
+			most = append(most, node)
+		}
+	}
+	return maxCount, most, nil
+}
+
+// CountSingleNeighbor counts the nodes with exactly one distinct neighbour.
+// Here a self-loop does make a node its own neighbour, so a node whose only
+// edge is a loop counts, and one with a loop and another neighbour does not.
+func CountSingleNeighbor(ctx context.Context, st store.GraphStore) (int, error) {
+	neighbors, err := neighborSets(ctx, st, true)
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, set := range neighbors {
+		if len(set) == 1 {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// neighborSets builds the undirected neighbourhood of every node from one
+// scan of the edges. Self-loops are only kept when selfLoops is set.
+func neighborSets(ctx context.Context, st store.GraphStore, selfLoops bool) (map[string]map[string]bool, error) {
+	neighbors := make(map[string]map[string]bool)
+	add := func(a, b string) {
+		if neighbors[a] == nil {
+			neighbors[a] = make(map[string]bool)
+		}
+		neighbors[a][b] = true
+	}
+	err := st.Edges(ctx, func(e model.Edge) error {
+		if selfLoops || e.FromNode != e.ToNode {
+			add(e.FromNode, e.ToNode)
+			add(e.ToNode, e.FromNode)
+		}
+		return nil
+	})
+	return neighbors, err
+}
@@ -0,0 +1,47 @@
+// Package graphrpc defines the Graph gRPC service that dbcli serve
+// --grpc-addr answers, and a Go client for it. The service and its
+// messages are declared in graph.proto; graph.pb.go and graph_grpc.pb.go are
+// generated from it. Queries with a small answer are unary calls; the ones
+// that can return a hub node's worth of rows, and the full scans, are
+// server streams that send each row as the store reads it. The deadline and
+// cancellation of a call reach the store, so a client that gives up stops
+// the Cassandra read.
+package graphrpc
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative graph.proto
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Client calls the Graph service. Streaming calls return a stream to Recv
+// from until io.EOF; cancelling ctx ends the stream and the server's read.
+type Client struct {
+	GraphClient
+	conn *grpc.ClientConn
+}
+
+// Dial connects to a dbcli serve --grpc-addr. The connection is plaintext
+// unless opts bring transport credentials.
+func Dial(target string, opts ...grpc.DialOption) (*Client, error) {
+	opts = append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, opts...)
+	conn, err := grpc.NewClient(target, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{GraphClient: NewGraphClient(conn), conn: conn}, nil
+}
+
+// NewClient uses a connection the caller owns.
+func NewClient(cc grpc.ClientConnInterface) *Client {
+	return &Client{GraphClient: NewGraphClient(cc)}
+}
+
+// Close closes the connection Dial opened.
+func (c *Client) Close() error {
+	if c.conn == nil {
+		return nil
+	}
+	return c.conn.Close()
+}
@@ -0,0 +1,939 @@
+// The Graph service that dbcli serve --grpc-addr answers. Regenerate the Go
+// code with go generate ./graphrpc after changing this file.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: graph.proto
+
+package graphrpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Graph_Node_FullMethodName              = "/dbcli.graph.v1.Graph/Node"
+	Graph_Successors_FullMethodName        = "/dbcli.graph.v1.Graph/Successors"
+	Graph_CountSuccessors_FullMethodName   = "/dbcli.graph.v1.Graph/CountSuccessors"
+	Graph_Predecessors_FullMethodName      = "/dbcli.graph.v1.Graph/Predecessors"
+	Graph_CountPredecessors_FullMethodName = "/dbcli.graph.v1.Graph/CountPredecessors"
+	Graph_Neighbors_FullMethodName         = "/dbcli.graph.v1.Graph/Neighbors"
+	Graph_SuccessorsPage_FullMethodName    = "/dbcli.graph.v1.Graph/SuccessorsPage"
+	Graph_PredecessorsPage_FullMethodName  = "/dbcli.graph.v1.Graph/PredecessorsPage"
+	Graph_NeighborsPage_FullMethodName     = "/dbcli.graph.v1.Graph/NeighborsPage"
+	Graph_Grandchildren_FullMethodName     = "/dbcli.graph.v1.Graph/Grandchildren"
+	Graph_Grandparents_FullMethodName      = "/dbcli.graph.v1.Graph/Grandparents"
+	Graph_Similar_FullMethodName           = "/dbcli.graph.v1.Graph/Similar"
+	Graph_ShortestPath_FullMethodName      = "/dbcli.graph.v1.Graph/ShortestPath"
+	Graph_Frontiers_FullMethodName         = "/dbcli.graph.v1.Graph/Frontiers"
+	Graph_Synonyms_FullMethodName          = "/dbcli.graph.v1.Graph/Synonyms"
+	Graph_Antonyms_FullMethodName          = "/dbcli.graph.v1.Graph/Antonyms"
+	Graph_Stats_FullMethodName             = "/dbcli.graph.v1.Graph/Stats"
+	Graph_Degree_FullMethodName            = "/dbcli.graph.v1.Graph/Degree"
+	Graph_ScanNodes_FullMethodName         = "/dbcli.graph.v1.Graph/ScanNodes"
+	Graph_ScanEdges_FullMethodName         = "/dbcli.graph.v1.Graph/ScanEdges"
+	Graph_Rename_FullMethodName            = "/dbcli.graph.v1.Graph/Rename"
+)
+
+// GraphClient is the client API for Graph service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type GraphClient interface {
+	// Node returns the labels of a node, or NotFound.
+	Node(ctx context.Context, in *NodeRequest, opts ...grpc.CallOption) (*Node, error)
+	// Successors streams the outgoing edges of a node (query one).
+	Successors(ctx context.Context, in *NodeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Edge], error)
+	// CountSuccessors counts them (query two).
+	CountSuccessors(ctx context.Context, in *NodeRequest, opts ...grpc.CallOption) (*Count, error)
+	// Predecessors streams the incoming edges of a node (query three).
+	Predecessors(ctx context.Context, in *NodeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Edge], error)
+	// CountPredecessors counts the distinct predecessors (query four).
+	CountPredecessors(ctx context.Context, in *NodeRequest, opts ...grpc.CallOption) (*Count, error)
+	// Neighbors returns the neighbours in either direction (query five).
+	Neighbors(ctx context.Context, in *NodeRequest, opts ...grpc.CallOption) (*NodeList, error)
+	// SuccessorsPage, PredecessorsPage and NeighborsPage walk the same lists
+	// a page at a time, with the store's cursor.
+	SuccessorsPage(ctx context.Context, in *PageRequest, opts ...grpc.CallOption) (*EdgePage, error)
+	PredecessorsPage(ctx context.Context, in *PageRequest, opts ...grpc.CallOption) (*EdgePage, error)
+	NeighborsPage(ctx context.Context, in *PageRequest, opts ...grpc.CallOption) (*NodePage, error)
+	// Grandchildren and Grandparents are queries seven and eight.
+	Grandchildren(ctx context.Context, in *NodeRequest, opts ...grpc.CallOption) (*NodeList, error)
+	Grandparents(ctx context.Context, in *NodeRequest, opts ...grpc.CallOption) (*NodeList, error)
+	// Similar streams the nodes sharing a parent or child over the same
+	// relation as they are found (query fifteen).
+	Similar(ctx context.Context, in *NodeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[NodeName], error)
+	// ShortestPath is query sixteen.
+	ShortestPath(ctx context.Context, in *PathRequest, opts ...grpc.CallOption) (*Path, error)
+	// Frontiers streams the breadth-first levels around a node.
+	Frontiers(ctx context.Context, in *FrontierRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Frontier], error)
+	// Synonyms and Antonyms are queries seventeen and eighteen.
+	Synonyms(ctx context.Context, in *DistanceRequest, opts ...grpc.CallOption) (*ReachedList, error)
+	Antonyms(ctx context.Context, in *DistanceRequest, opts ...grpc.CallOption) (*ReachedList, error)
+	// Stats counts the nodes and edges (query nine).
+	Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*Stats, error)
+	// Degree runs the degree scans (queries ten to thirteen).
+	Degree(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*Degree, error)
+	// ScanNodes and ScanEdges stream every node or edge.
+	ScanNodes(ctx context.Context, in *ScanRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[NodeName], error)
+	ScanEdges(ctx context.Context, in *ScanRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Edge], error)
+	// Rename moves a node (query fourteen), if the server is writable.
+	Rename(ctx context.Context, in *RenameRequest, opts ...grpc.CallOption) (*RenameReply, error)
+}
+
+type graphClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGraphClient(cc grpc.ClientConnInterface) GraphClient {
+	return &graphClient{cc}
+}
+
+func (c *graphClient) Node(ctx context.Context, in *NodeRequest, opts ...grpc.CallOption) (*Node, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Node)
+	err := c.cc.Invoke(ctx, Graph_Node_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphClient) Successors(ctx context.Context, in *NodeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Edge], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Graph_ServiceDesc.Streams[0], Graph_Successors_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[NodeRequest, Edge]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Graph_SuccessorsClient = grpc.ServerStreamingClient[Edge]
+
+func (c *graphClient) CountSuccessors(ctx context.Context, in *NodeRequest, opts ...grpc.CallOption) (*Count, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Count)
+	err := c.cc.Invoke(ctx, Graph_CountSuccessors_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphClient) Predecessors(ctx context.Context, in *NodeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Edge], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Graph_ServiceDesc.Streams[1], Graph_Predecessors_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[NodeRequest, Edge]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Graph_PredecessorsClient = grpc.ServerStreamingClient[Edge]
+
+func (c *graphClient) CountPredecessors(ctx context.Context, in *NodeRequest, opts ...grpc.CallOption) (*Count, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Count)
+	err := c.cc.Invoke(ctx, Graph_CountPredecessors_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphClient) Neighbors(ctx context.Context, in *NodeRequest, opts ...grpc.CallOption) (*NodeList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NodeList)
+	err := c.cc.Invoke(ctx, Graph_Neighbors_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphClient) SuccessorsPage(ctx context.Context, in *PageRequest, opts ...grpc.CallOption) (*EdgePage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EdgePage)
+	err := c.cc.Invoke(ctx, Graph_SuccessorsPage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphClient) PredecessorsPage(ctx context.Context, in *PageRequest, opts ...grpc.CallOption) (*EdgePage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EdgePage)
+	err := c.cc.Invoke(ctx, Graph_PredecessorsPage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphClient) NeighborsPage(ctx context.Context, in *PageRequest, opts ...grpc.CallOption) (*NodePage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NodePage)
+	err := c.cc.Invoke(ctx, Graph_NeighborsPage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphClient) Grandchildren(ctx context.Context, in *NodeRequest, opts ...grpc.CallOption) (*NodeList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NodeList)
+	err := c.cc.Invoke(ctx, Graph_Grandchildren_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphClient) Grandparents(ctx context.Context, in *NodeRequest, opts ...grpc.CallOption) (*NodeList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NodeList)
+	err := c.cc.Invoke(ctx, Graph_Grandparents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphClient) Similar(ctx context.Context, in *NodeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[NodeName], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Graph_ServiceDesc.Streams[2], Graph_Similar_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[NodeRequest, NodeName]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Graph_SimilarClient = grpc.ServerStreamingClient[NodeName]
+
+func (c *graphClient) ShortestPath(ctx context.Context, in *PathRequest, opts ...grpc.CallOption) (*Path, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Path)
+	err := c.cc.Invoke(ctx, Graph_ShortestPath_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphClient) Frontiers(ctx context.Context, in *FrontierRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Frontier], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Graph_ServiceDesc.Streams[3], Graph_Frontiers_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[FrontierRequest, Frontier]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Graph_FrontiersClient = grpc.ServerStreamingClient[Frontier]
+
+func (c *graphClient) Synonyms(ctx context.Context, in *DistanceRequest, opts ...grpc.CallOption) (*ReachedList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReachedList)
+	err := c.cc.Invoke(ctx, Graph_Synonyms_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphClient) Antonyms(ctx context.Context, in *DistanceRequest, opts ...grpc.CallOption) (*ReachedList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReachedList)
+	err := c.cc.Invoke(ctx, Graph_Antonyms_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphClient) Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*Stats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Stats)
+	err := c.cc.Invoke(ctx, Graph_Stats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphClient) Degree(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*Degree, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Degree)
+	err := c.cc.Invoke(ctx, Graph_Degree_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphClient) ScanNodes(ctx context.Context, in *ScanRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[NodeName], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Graph_ServiceDesc.Streams[4], Graph_ScanNodes_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ScanRequest, NodeName]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Graph_ScanNodesClient = grpc.ServerStreamingClient[NodeName]
+
+func (c *graphClient) ScanEdges(ctx context.Context, in *ScanRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Edge], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Graph_ServiceDesc.Streams[5], Graph_ScanEdges_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ScanRequest, Edge]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Graph_ScanEdgesClient = grpc.ServerStreamingClient[Edge]
+
+func (c *graphClient) Rename(ctx context.Context, in *RenameRequest, opts ...grpc.CallOption) (*RenameReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RenameReply)
+	err := c.cc.Invoke(ctx, Graph_Rename_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GraphServer is the server API for Graph service.
+// All implementations must embed UnimplementedGraphServer
+// for forward compatibility.
+type GraphServer interface {
+	// Node returns the labels of a node, or NotFound.
+	Node(context.Context, *NodeRequest) (*Node, error)
+	// Successors streams the outgoing edges of a node (query one).
+	Successors(*NodeRequest, grpc.ServerStreamingServer[Edge]) error
+	// CountSuccessors counts them (query two).
+	CountSuccessors(context.Context, *NodeRequest) (*Count, error)
+	// Predecessors streams the incoming edges of a node (query three).
+	Predecessors(*NodeRequest, grpc.ServerStreamingServer[Edge]) error
+	// CountPredecessors counts the distinct predecessors (query four).
+	CountPredecessors(context.Context, *NodeRequest) (*Count, error)
+	// Neighbors returns the neighbours in either direction (query five).
+	Neighbors(context.Context, *NodeRequest) (*NodeList, error)
+	// SuccessorsPage, PredecessorsPage and NeighborsPage walk the same lists
+	// a page at a time, with the store's cursor.
+	SuccessorsPage(context.Context, *PageRequest) (*EdgePage, error)
+	PredecessorsPage(context.Context, *PageRequest) (*EdgePage, error)
+	NeighborsPage(context.Context, *PageRequest) (*NodePage, error)
+	// Grandchildren and Grandparents are queries seven and eight.
+	Grandchildren(context.Context, *NodeRequest) (*NodeList, error)
+	Grandparents(context.Context, *NodeRequest) (*NodeList, error)
+	// Similar streams the nodes sharing a parent or child over the same
+	// relation as they are found (query fifteen).
+	Similar(*NodeRequest, grpc.ServerStreamingServer[NodeName]) error
+	// ShortestPath is query sixteen.
+	ShortestPath(context.Context, *PathRequest) (*Path, error)
+	// Frontiers streams the breadth-first levels around a node.
+	Frontiers(*FrontierRequest, grpc.ServerStreamingServer[Frontier]) error
+	// Synonyms and Antonyms are queries seventeen and eighteen.
+	Synonyms(context.Context, *DistanceRequest) (*ReachedList, error)
+	Antonyms(context.Context, *DistanceRequest) (*ReachedList, error)
+	// Stats counts the nodes and edges (query nine).
+	Stats(context.Context, *StatsRequest) (*Stats, error)
+	// Degree runs the degree scans (queries ten to thirteen).
+	Degree(context.Context, *StatsRequest) (*Degree, error)
+	// ScanNodes and ScanEdges stream every node or edge.
+	ScanNodes(*ScanRequest, grpc.ServerStreamingServer[NodeName]) error
+	ScanEdges(*ScanRequest, grpc.ServerStreamingServer[Edge]) error
+	// Rename moves a node (query fourteen), if the server is writable.
+	Rename(context.Context, *RenameRequest) (*RenameReply, error)
+	mustEmbedUnimplementedGraphServer()
+}
+
+// UnimplementedGraphServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedGraphServer struct{}
+
+func (UnimplementedGraphServer) Node(context.Context, *NodeRequest) (*Node, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Node not implemented")
+}
+func (UnimplementedGraphServer) Successors(*NodeRequest, grpc.ServerStreamingServer[Edge]) error {
+	return status.Errorf(codes.Unimplemented, "method Successors not implemented")
+}
+func (UnimplementedGraphServer) CountSuccessors(context.Context, *NodeRequest) (*Count, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CountSuccessors not implemented")
+}
+func (UnimplementedGraphServer) Predecessors(*NodeRequest, grpc.ServerStreamingServer[Edge]) error {
+	return status.Errorf(codes.Unimplemented, "method Predecessors not implemented")
+}
+func (UnimplementedGraphServer) CountPredecessors(context.Context, *NodeRequest) (*Count, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CountPredecessors not implemented")
+}
+func (UnimplementedGraphServer) Neighbors(context.Context, *NodeRequest) (*NodeList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Neighbors not implemented")
+}
+func (UnimplementedGraphServer) SuccessorsPage(context.Context, *PageRequest) (*EdgePage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SuccessorsPage not implemented")
+}
+func (UnimplementedGraphServer) PredecessorsPage(context.Context, *PageRequest) (*EdgePage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PredecessorsPage not implemented")
+}
+func (UnimplementedGraphServer) NeighborsPage(context.Context, *PageRequest) (*NodePage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NeighborsPage not implemented")
+}
+func (UnimplementedGraphServer) Grandchildren(context.Context, *NodeRequest) (*NodeList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Grandchildren not implemented")
+}
+func (UnimplementedGraphServer) Grandparents(context.Context, *NodeRequest) (*NodeList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Grandparents not implemented")
+}
+func (UnimplementedGraphServer) Similar(*NodeRequest, grpc.ServerStreamingServer[NodeName]) error {
+	return status.Errorf(codes.Unimplemented, "method Similar not implemented")
+}
+func (UnimplementedGraphServer) ShortestPath(context.Context, *PathRequest) (*Path, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShortestPath not implemented")
+}
+func (UnimplementedGraphServer) Frontiers(*FrontierRequest, grpc.ServerStreamingServer[Frontier]) error {
+	return status.Errorf(codes.Unimplemented, "method Frontiers not implemented")
+}
+func (UnimplementedGraphServer) Synonyms(context.Context, *DistanceRequest) (*ReachedList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Synonyms not implemented")
+}
+func (UnimplementedGraphServer) Antonyms(context.Context, *DistanceRequest) (*ReachedList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Antonyms not implemented")
+}
+func (UnimplementedGraphServer) Stats(context.Context, *StatsRequest) (*Stats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Stats not implemented")
+}
+func (UnimplementedGraphServer) Degree(context.Context, *StatsRequest) (*Degree, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Degree not implemented")
+}
+func (UnimplementedGraphServer) ScanNodes(*ScanRequest, grpc.ServerStreamingServer[NodeName]) error {
+	return status.Errorf(codes.Unimplemented, "method ScanNodes not implemented")
+}
+func (UnimplementedGraphServer) ScanEdges(*ScanRequest, grpc.ServerStreamingServer[Edge]) error {
+	return status.Errorf(codes.Unimplemented, "method ScanEdges not implemented")
+}
+func (UnimplementedGraphServer) Rename(context.Context, *RenameRequest) (*RenameReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Rename not implemented")
+}
+func (UnimplementedGraphServer) mustEmbedUnimplementedGraphServer() {}
+func (UnimplementedGraphServer) testEmbeddedByValue()               {}
+
+// UnsafeGraphServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GraphServer will
+// result in compilation errors.
+type UnsafeGraphServer interface {
+	mustEmbedUnimplementedGraphServer()
+}
+
+func RegisterGraphServer(s grpc.ServiceRegistrar, srv GraphServer) {
+	// If the following call pancis, it indicates UnimplementedGraphServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Graph_ServiceDesc, srv)
+}
+
+func _Graph_Node_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServer).Node(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Graph_Node_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServer).Node(ctx, req.(*NodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Graph_Successors_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(NodeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GraphServer).Successors(m, &grpc.GenericServerStream[NodeRequest, Edge]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Graph_SuccessorsServer = grpc.ServerStreamingServer[Edge]
+
+func _Graph_CountSuccessors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServer).CountSuccessors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Graph_CountSuccessors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServer).CountSuccessors(ctx, req.(*NodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Graph_Predecessors_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(NodeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GraphServer).Predecessors(m, &grpc.GenericServerStream[NodeRequest, Edge]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Graph_PredecessorsServer = grpc.ServerStreamingServer[Edge]
+
+func _Graph_CountPredecessors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServer).CountPredecessors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Graph_CountPredecessors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServer).CountPredecessors(ctx, req.(*NodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Graph_Neighbors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServer).Neighbors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Graph_Neighbors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServer).Neighbors(ctx, req.(*NodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Graph_SuccessorsPage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServer).SuccessorsPage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Graph_SuccessorsPage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServer).SuccessorsPage(ctx, req.(*PageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Graph_PredecessorsPage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServer).PredecessorsPage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Graph_PredecessorsPage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServer).PredecessorsPage(ctx, req.(*PageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Graph_NeighborsPage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServer).NeighborsPage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Graph_NeighborsPage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServer).NeighborsPage(ctx, req.(*PageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Graph_Grandchildren_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServer).Grandchildren(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Graph_Grandchildren_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServer).Grandchildren(ctx, req.(*NodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Graph_Grandparents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServer).Grandparents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Graph_Grandparents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServer).Grandparents(ctx, req.(*NodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Graph_Similar_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(NodeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GraphServer).Similar(m, &grpc.GenericServerStream[NodeRequest, NodeName]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Graph_SimilarServer = grpc.ServerStreamingServer[NodeName]
+
+func _Graph_ShortestPath_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PathRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServer).ShortestPath(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Graph_ShortestPath_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServer).ShortestPath(ctx, req.(*PathRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Graph_Frontiers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FrontierRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GraphServer).Frontiers(m, &grpc.GenericServerStream[FrontierRequest, Frontier]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Graph_FrontiersServer = grpc.ServerStreamingServer[Frontier]
+
+func _Graph_Synonyms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DistanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServer).Synonyms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Graph_Synonyms_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServer).Synonyms(ctx, req.(*DistanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Graph_Antonyms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DistanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServer).Antonyms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Graph_Antonyms_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServer).Antonyms(ctx, req.(*DistanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Graph_Stats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServer).Stats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Graph_Stats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServer).Stats(ctx, req.(*StatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Graph_Degree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServer).Degree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Graph_Degree_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServer).Degree(ctx, req.(*StatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Graph_ScanNodes_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ScanRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GraphServer).ScanNodes(m, &grpc.GenericServerStream[ScanRequest, NodeName]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Graph_ScanNodesServer = grpc.ServerStreamingServer[NodeName]
+
+func _Graph_ScanEdges_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ScanRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GraphServer).ScanEdges(m, &grpc.GenericServerStream[ScanRequest, Edge]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Graph_ScanEdgesServer = grpc.ServerStreamingServer[Edge]
+
+func _Graph_Rename_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServer).Rename(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Graph_Rename_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServer).Rename(ctx, req.(*RenameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Graph_ServiceDesc is the grpc.ServiceDesc for Graph service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Graph_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "dbcli.graph.v1.Graph",
+	HandlerType: (*GraphServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Node",
+			Handler:    _Graph_Node_Handler,
+		},
+		{
+			MethodName: "CountSuccessors",
+			Handler:    _Graph_CountSuccessors_Handler,
+		},
+		{
+			MethodName: "CountPredecessors",
+			Handler:    _Graph_CountPredecessors_Handler,
+		},
+		{
+			MethodName: "Neighbors",
+			Handler:    _Graph_Neighbors_Handler,
+		},
+		{
+			MethodName: "SuccessorsPage",
+			Handler:    _Graph_SuccessorsPage_Handler,
+		},
+		{
+			MethodName: "PredecessorsPage",
+			Handler:    _Graph_PredecessorsPage_Handler,
+		},
+		{
+			MethodName: "NeighborsPage",
+			Handler:    _Graph_NeighborsPage_Handler,
+		},
+		{
+			MethodName: "Grandchildren",
+			Handler:    _Graph_Grandchildren_Handler,
+		},
+		{
+			MethodName: "Grandparents",
+			Handler:    _Graph_Grandparents_Handler,
+		},
+		{
+			MethodName: "ShortestPath",
+			Handler:    _Graph_ShortestPath_Handler,
+		},
+		{
+			MethodName: "Synonyms",
+			Handler:    _Graph_Synonyms_Handler,
+		},
+		{
+			MethodName: "Antonyms",
+			Handler:    _Graph_Antonyms_Handler,
+		},
+		{
+			MethodName: "Stats",
+			Handler:    _Graph_Stats_Handler,
+		},
+		{
+			MethodName: "Degree",
+			Handler:    _Graph_Degree_Handler,
+		},
+		{
+			MethodName: "Rename",
+			Handler:    _Graph_Rename_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Successors",
+			Handler:       _Graph_Successors_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Predecessors",
+			Handler:       _Graph_Predecessors_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Similar",
+			Handler:       _Graph_Similar_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Frontiers",
+			Handler:       _Graph_Frontiers_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ScanNodes",
+			Handler:       _Graph_ScanNodes_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ScanEdges",
+			Handler:       _Graph_ScanEdges_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "graph.proto",
+}
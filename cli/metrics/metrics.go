@@ -0,0 +1,144 @@
+// Package metrics measures one command: wall and CPU time, allocation, GC,
+// and the work it asked of the store. Counters travel in the context, so
+// every store call and Cassandra round trip made under that context is
+// counted against the command that made it.
+package metrics
+
+import (
+	"context"
+	"runtime"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+// Counters accumulate the store work done under one context. A nil
+// *Counters counts nothing, so callers never need to check.
+type Counters struct {
+	storeCalls    atomic.Int64
+	rowsScanned   atomic.Int64
+	roundTrips    atomic.Int64
+	cassandraRows atomic.Int64
+}
+
+type countersKey struct{}
+
+// WithCounters returns a context that carries fresh counters.
+func WithCounters(ctx context.Context) (context.Context, *Counters) {
+	c := &Counters{}
+	return context.WithValue(ctx, countersKey{}, c), c
+}
+
+// FromContext returns the counters carried by ctx, or nil.
+func FromContext(ctx context.Context) *Counters {
+	c, _ := ctx.Value(countersKey{}).(*Counters)
+	return c
+}
+
+// StoreCall records one store call that returned rows rows.
+func (c *Counters) StoreCall(rows int) {
+	if c == nil {
+		return
+	}
+	c.storeCalls.Add(1)
+	c.rowsScanned.Add(int64(rows))
+}
+
+// Observer counts Cassandra round trips, every page of a paged query
+// included, against the counters in the query's context. It is installed on
+// the cluster configuration.
+type Observer struct{}
+
+func (Observer) ObserveQuery(ctx context.Context, q gocql.ObservedQuery) {
+	if c := FromContext(ctx); c != nil {
+		c.roundTrips.Add(1)
+		c.cassandraRows.Add(int64(q.Rows))
+	}
+}
+
+func (Observer) ObserveBatch(ctx context.Context, b gocql.ObservedBatch) {
+	if c := FromContext(ctx); c != nil {
+		c.roundTrips.Add(1)
+	}
+}
+
+// Report is the measurement of one command. Durations are in milliseconds
+// so the JSON form is easy to plot.
+type Report struct {
+	Command       string  `json:"command"`
+	WallMs        float64 `json:"wall_ms"`
+	CPUUserMs     float64 `json:"cpu_user_ms"`
+	CPUSysMs      float64 `json:"cpu_sys_ms"`
+	AllocBytes    uint64  `json:"alloc_bytes"`
+	Mallocs       uint64  `json:"mallocs"`
+	HeapDelta     int64   `json:"heap_delta_bytes"`
+	GCCycles      uint32  `json:"gc_cycles"`
+	GCPauseMs     float64 `json:"gc_pause_ms"`
+	StoreCalls    int64   `json:"store_calls"`
+	RowsScanned   int64   `json:"rows_scanned"`
+	RoundTrips    int64   `json:"cassandra_round_trips"`
+	CassandraRows int64   `json:"cassandra_rows"`
+	RowsPerSec    float64 `json:"rows_per_sec"`
+}
+
+// Measurement is a command being measured.
+type Measurement struct {
+	command  string
+	start    time.Time
+	rusage   syscall.Rusage
+	mem      runtime.MemStats
+	counters *Counters
+}
+
+// Start begins measuring command. Run the command with the returned
+// context so its store work is counted.
+func Start(ctx context.Context, command string) (context.Context, *Measurement) {
+	ctx, counters := WithCounters(ctx)
+	m := &Measurement{command: command, counters: counters}
+	runtime.ReadMemStats(&m.mem)
+	_ = syscall.Getrusage(syscall.RUSAGE_SELF, &m.rusage)
+	m.start = time.Now()
+	return ctx, m
+}
+
+// Stop ends the measurement. Allocation is taken from the cumulative
+// counters, which only grow, so a GC during the command cannot make it
+// negative; the change in live heap is reported separately and may be.
+func (m *Measurement) Stop() Report {
+	wall := time.Since(m.start)
+	var rusage syscall.Rusage
+	_ = syscall.Getrusage(syscall.RUSAGE_SELF, &rusage)
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	r := Report{
+		Command:       m.command,
+		WallMs:        ms(wall),
+		CPUUserMs:     ms(time.Duration(rusage.Utime.Nano() - m.rusage.Utime.Nano())),
+		CPUSysMs:      ms(time.Duration(rusage.Stime.Nano() - m.rusage.Stime.Nano())),
+		AllocBytes:    mem.TotalAlloc - m.mem.TotalAlloc,
+		Mallocs:       mem.Mallocs - m.mem.Mallocs,
+		HeapDelta:     int64(mem.HeapAlloc) - int64(m.mem.HeapAlloc),
+		GCCycles:      mem.NumGC - m.mem.NumGC,
+		GCPauseMs:     ms(time.Duration(mem.PauseTotalNs - m.mem.PauseTotalNs)),
+		StoreCalls:    m.counters.storeCalls.Load(),
+		RowsScanned:   m.counters.rowsScanned.Load(),
+		RoundTrips:    m.counters.roundTrips.Load(),
+		CassandraRows: m.counters.cassandraRows.Load(),
+	}
+	if wall > 0 {
+		r.RowsPerSec = float64(r.RowsScanned) / wall.Seconds()
+	}
+	return r
+}
+
+// Wall returns the wall time of the report.
+func (r Report) Wall() time.Duration {
+	return time.Duration(r.WallMs * float64(time.Millisecond))
+}
+
+func ms(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,130 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/DavidZaya21/parser/model"
+	"github.com/DavidZayar/cli/store"
+)
+
+// Store counts every call made to st, and the rows each returned, against
+// the counters in the call's context. It works the same on every backend.
+func Store(st store.GraphStore) store.GraphStore {
+	return countingStore{st}
+}
+
+type countingStore struct {
+	store.GraphStore
+}
+
+func (s countingStore) Successors(ctx context.Context, node string) ([]model.Edge, error) {
+	edges, err := s.GraphStore.Successors(ctx, node)
+	FromContext(ctx).StoreCall(len(edges))
+	return edges, err
+}
+
+func (s countingStore) Predecessors(ctx context.Context, node string) ([]model.Edge, error) {
+	edges, err := s.GraphStore.Predecessors(ctx, node)
+	FromContext(ctx).StoreCall(len(edges))
+	return edges, err
+}
+
+func (s countingStore) SuccessorsByRelation(ctx context.Context, node string, rel store.Relations) ([]model.Edge, error) {
+	edges, err := s.GraphStore.SuccessorsByRelation(ctx, node, rel)
+	FromContext(ctx).StoreCall(len(edges))
+	return edges, err
+}
+
+func (s countingStore) PredecessorsByRelation(ctx context.Context, node string, rel store.Relations) ([]model.Edge, error) {
+	edges, err := s.GraphStore.PredecessorsByRelation(ctx, node, rel)
+	FromContext(ctx).StoreCall(len(edges))
+	return edges, err
+}
+
+func (s countingStore) EachSuccessor(ctx context.Context, node string, rel store.Relations, fn func(e model.Edge) error) error {
+	rows := 0
+	err := s.GraphStore.EachSuccessor(ctx, node, rel, func(e model.Edge) error {
+		rows++
+		return fn(e)
+	})
+	FromContext(ctx).StoreCall(rows)
+	return err
+}
+
+func (s countingStore) EachPredecessor(ctx context.Context, node string, rel store.Relations, fn func(e model.Edge) error) error {
+	rows := 0
+	err := s.GraphStore.EachPredecessor(ctx, node, rel, func(e model.Edge) error {
+		rows++
+		return fn(e)
+	})
+	FromContext(ctx).StoreCall(rows)
+	return err
+}
+
+func (s countingStore) SuccessorsPage(ctx context.Context, node string, p store.Page) ([]model.Edge, string, error) {
+	edges, next, err := s.GraphStore.SuccessorsPage(ctx, node, p)
+	FromContext(ctx).StoreCall(len(edges))
+	return edges, next, err
+}
+
+func (s countingStore) PredecessorsPage(ctx context.Context, node string, p store.Page) ([]model.Edge, string, error) {
+	edges, next, err := s.GraphStore.PredecessorsPage(ctx, node, p)
+	FromContext(ctx).StoreCall(len(edges))
+	return edges, next, err
+}
+
+func (s countingStore) NeighborsPage(ctx context.Context, node string, p store.Page) ([]string, string, error) {
+	neighbors, next, err := s.GraphStore.NeighborsPage(ctx, node, p)
+	FromContext(ctx).StoreCall(len(neighbors))
+	return neighbors, next, err
+}
+
+func (s countingStore) Neighbors(ctx context.Context, node string) ([]string, error) {
+	neighbors, err := s.GraphStore.Neighbors(ctx, node)
+	FromContext(ctx).StoreCall(len(neighbors))
+	return neighbors, err
+}
+
+func (s countingStore) Labels(ctx context.Context, node string) ([]string, error) {
+	labels, err := s.GraphStore.Labels(ctx, node)
+	FromContext(ctx).StoreCall(len(labels))
+	return labels, err
+}
+
+func (s countingStore) Nodes(ctx context.Context, fn func(name string) error) error {
+	rows := 0
+	err := s.GraphStore.Nodes(ctx, func(name string) error {
+		rows++
+		return fn(name)
+	})
+	FromContext(ctx).StoreCall(rows)
+	return err
+}
+
+func (s countingStore) NodesWithPrefix(ctx context.Context, prefix string, limit int) ([]string, error) {
+	names, err := s.GraphStore.NodesWithPrefix(ctx, prefix, limit)
+	FromContext(ctx).StoreCall(len(names))
+	return names, err
+}
+
+func (s countingStore) Edges(ctx context.Context, fn func(e model.Edge) error) error {
+	rows := 0
+	err := s.GraphStore.Edges(ctx, func(e model.Edge) error {
+		rows++
+		return fn(e)
+	})
+	FromContext(ctx).StoreCall(rows)
+	return err
+}
+
+func (s countingStore) RenameNode(ctx context.Context, oldName, newName string) error {
+	err := s.GraphStore.RenameNode(ctx, oldName, newName)
+	FromContext(ctx).StoreCall(0)
+	return err
+}
+
+func (s countingStore) Stats(ctx context.Context) (store.Stats, error) {
+	stats, err := s.GraphStore.Stats(ctx)
+	FromContext(ctx).StoreCall(0)
+	return stats, err
+}
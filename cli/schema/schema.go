@@ -0,0 +1,291 @@
+// Package schema creates and upgrades the graph keyspace through versioned
+// CQL migrations, recording what has been applied in schema_migrations.
+package schema
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+//go:embed migrations/*.cql
+var migrationFiles embed.FS
+
+var (
+	identifier    = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]{0,47}$`)
+	migrationName = regexp.MustCompile(`^(\d+)_(\w+)\.cql$`)
+)
+
+// Compaction strategies accepted by Options.Compaction, keyed by short name.
+var compactionClasses = map[string]string{
+	"stcs": "SizeTieredCompactionStrategy",
+	"lcs":  "LeveledCompactionStrategy",
+	"twcs": "TimeWindowCompactionStrategy",
+}
+
+// Options controls how the keyspace and its tables are created.
+type Options struct {
+	Keyspace string
+	// ReplicationClass is SimpleStrategy or NetworkTopologyStrategy.
+	ReplicationClass  string
+	ReplicationFactor int
+	// Datacenters maps datacenter name to replication factor and is only used
+	// with NetworkTopologyStrategy.
+	Datacenters map[string]int
+	// Compaction is stcs, lcs or twcs and applies to tables as they are created.
+	Compaction string
+}
+
+// Migration is one versioned CQL file.
+type Migration struct {
+	Version    int
+	Name       string
+	Checksum   string
+	Statements []string
+}
+
+// Status describes a migration and whether the keyspace has it.
+type Status struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+	// Modified is set when the file changed after it was applied.
+	Modified bool
+}
+
+func (o Options) validate() error {
+	if !identifier.MatchString(o.Keyspace) {
+		return fmt.Errorf("invalid keyspace name %q", o.Keyspace)
+	}
+	if _, ok := compactionClasses[o.Compaction]; !ok {
+		return fmt.Errorf("unknown compaction strategy %q, expected stcs, lcs or twcs", o.Compaction)
+	}
+	switch o.ReplicationClass {
+	case "SimpleStrategy":
+		if o.ReplicationFactor < 1 {
+			return fmt.Errorf("replication factor must be at least 1")
+		}
+	case "NetworkTopologyStrategy":
+		if len(o.Datacenters) == 0 {
+			return fmt.Errorf("NetworkTopologyStrategy needs at least one datacenter")
+		}
+		for dc, rf := range o.Datacenters {
+			if !identifier.MatchString(dc) || rf < 1 {
+				return fmt.Errorf("invalid datacenter replication %s=%d", dc, rf)
+			}
+		}
+	default:
+		return fmt.Errorf("unknown replication class %q", o.ReplicationClass)
+	}
+	return nil
+}
+
+func (o Options) replication() string {
+	if o.ReplicationClass == "SimpleStrategy" {
+		return fmt.Sprintf("{'class': 'SimpleStrategy', 'replication_factor': %d}", o.ReplicationFactor)
+	}
+	dcs := make([]string, 0, len(o.Datacenters))
+	for dc := range o.Datacenters {
+		dcs = append(dcs, dc)
+	}
+	sort.Strings(dcs)
+	parts := []string{"'class': 'NetworkTopologyStrategy'"}
+	for _, dc := range dcs {
+		parts = append(parts, fmt.Sprintf("'%s': %d", dc, o.Datacenters[dc]))
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
+func (o Options) compaction() string {
+	return fmt.Sprintf("{'class': '%s'}", compactionClasses[o.Compaction])
+}
+
+// Migrations returns every embedded migration rendered for opts, in version
+// order.
+func Migrations(opts Options) ([]Migration, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	entries, err := fs.ReadDir(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	params := struct{ Keyspace, Compaction string }{opts.Keyspace, opts.compaction()}
+	var migrations []Migration
+	for _, entry := range entries {
+		m := migrationName.FindStringSubmatch(entry.Name())
+		if m == nil {
+			return nil, fmt.Errorf("migration file %s does not match NNNN_name.cql", entry.Name())
+		}
+		version, _ := strconv.Atoi(m[1])
+		raw, err := migrationFiles.ReadFile(path.Join("migrations", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		tmpl, err := template.New(entry.Name()).Parse(string(raw))
+		if err != nil {
+			return nil, err
+		}
+		var rendered bytes.Buffer
+		if err := tmpl.Execute(&rendered, params); err != nil {
+			return nil, err
+		}
+		sum := sha256.Sum256(raw)
+		migrations = append(migrations, Migration{
+			Version:    version,
+			Name:       m[2],
+			Checksum:   hex.EncodeToString(sum[:]),
+			Statements: splitStatements(rendered.String()),
+		})
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Init creates the keyspace if needed and applies every pending migration.
+func Init(ctx context.Context, session *gocql.Session, opts Options) ([]Migration, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	stmt := fmt.Sprintf("CREATE KEYSPACE IF NOT EXISTS %s WITH replication = %s", opts.Keyspace, opts.replication())
+	if err := exec(ctx, session, stmt); err != nil {
+		return nil, fmt.Errorf("create keyspace: %w", err)
+	}
+	return Migrate(ctx, session, opts)
+}
+
+// Migrate applies the migrations the keyspace does not have yet, in order,
+// and returns the ones it applied.
+func Migrate(ctx context.Context, session *gocql.Session, opts Options) ([]Migration, error) {
+	statuses, err := Statuses(ctx, session, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var applied []Migration
+	for _, st := range statuses {
+		if st.Modified {
+			return applied, fmt.Errorf("migration %04d_%s changed after it was applied; add a new migration instead", st.Version, st.Name)
+		}
+		if st.Applied {
+			continue
+		}
+		for _, stmt := range st.Statements {
+			if err := exec(ctx, session, stmt); err != nil && !alreadyApplied(err) {
+				return applied, fmt.Errorf("migration %04d_%s: %w", st.Version, st.Name, err)
+			}
+		}
+		err := session.Query(
+			fmt.Sprintf("INSERT INTO %s.schema_migrations (version, name, checksum, applied_at) VALUES (?, ?, ?, ?)", opts.Keyspace),
+			st.Version, st.Name, st.Checksum, time.Now(),
+		).WithContext(ctx).Exec()
+		if err != nil {
+			return applied, fmt.Errorf("record migration %04d_%s: %w", st.Version, st.Name, err)
+		}
+		applied = append(applied, st.Migration)
+	}
+	return applied, nil
+}
+
+// Statuses reports every known migration and whether it has been applied.
+func Statuses(ctx context.Context, session *gocql.Session, opts Options) ([]Status, error) {
+	migrations, err := Migrations(opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureMigrationsTable(ctx, session, opts.Keyspace); err != nil {
+		return nil, err
+	}
+
+	type record struct {
+		checksum  string
+		appliedAt time.Time
+	}
+	recorded := make(map[int]record)
+	iter := session.Query(fmt.Sprintf("SELECT version, checksum, applied_at FROM %s.schema_migrations", opts.Keyspace)).
+		WithContext(ctx).Iter()
+	var version int
+	var rec record
+	for iter.Scan(&version, &rec.checksum, &rec.appliedAt) {
+		recorded[version] = rec
+	}
+	if err := iter.Close(); err != nil {
+		return nil, fmt.Errorf("read schema_migrations: %w", err)
+	}
+
+	statuses := make([]Status, 0, len(migrations))
+	for _, m := range migrations {
+		st := Status{Migration: m}
+		if rec, ok := recorded[m.Version]; ok {
+			st.Applied = true
+			st.AppliedAt = rec.appliedAt
+			st.Modified = rec.checksum != m.Checksum
+		}
+		statuses = append(statuses, st)
+	}
+	return statuses, nil
+}
+
+func ensureMigrationsTable(ctx context.Context, session *gocql.Session, keyspace string) error {
+	stmt := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s.schema_migrations (
+    version int PRIMARY KEY,
+    name text,
+    checksum text,
+    applied_at timestamp
+)`, keyspace)
+	if err := exec(ctx, session, stmt); err != nil {
+		return fmt.Errorf("create schema_migrations (does keyspace %s exist? run schema init): %w", keyspace, err)
+	}
+	return nil
+}
+
+// exec runs a DDL statement and waits for the cluster to agree on the result
+// before the next statement depends on it.
+func exec(ctx context.Context, session *gocql.Session, stmt string) error {
+	if err := session.Query(stmt).WithContext(ctx).Exec(); err != nil {
+		return err
+	}
+	return session.AwaitSchemaAgreement(ctx)
+}
+
+// alreadyApplied recognises errors from DDL whose effect is already in place,
+// such as adding a column a keyspace created by insert.sh already has.
+func alreadyApplied(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "conflicts with an existing column") ||
+		strings.Contains(msg, "already exists")
+}
+
+// splitStatements splits a CQL file on ';', dropping '--' comments and blank
+// statements.
+func splitStatements(cql string) []string {
+	var lines []string
+	for _, line := range strings.Split(cql, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "--") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	var statements []string
+	for _, stmt := range strings.Split(strings.Join(lines, "\n"), ";") {
+		if stmt = strings.TrimSpace(stmt); stmt != "" {
+			statements = append(statements, stmt)
+		}
+	}
+	return statements
+}
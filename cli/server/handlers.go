@@ -0,0 +1,350 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/DavidZaya21/parser/model"
+	"github.com/DavidZayar/cli/graph"
+	"github.com/DavidZayar/cli/store"
+)
+
+// Edge is one edge as seen from the node asked about: Node is the node at
+// the other end.
+type Edge struct {
+	Node          string `json:"node"`
+	Relation      string `json:"relation"`
+	RelationLabel string `json:"relation_label"`
+	Source        string `json:"source"`
+	Sentence      string `json:"sentence"`
+}
+
+func edge(other string, e model.Edge) Edge {
+	return Edge{
+		Node:          other,
+		Relation:      e.RelationType,
+		RelationLabel: e.RelationLabel,
+		Source:        e.Source,
+		Sentence:      e.Sentence,
+	}
+}
+
+// Node is a node and its labels.
+type Node struct {
+	Node   string   `json:"node"`
+	Labels []string `json:"labels"`
+}
+
+// Count answers the count endpoints.
+type Count struct {
+	Count int `json:"count"`
+}
+
+// Degree answers /stats/degree.
+type Degree struct {
+	WithoutSuccessors   int `json:"without_successors"`
+	WithoutPredecessors int `json:"without_predecessors"`
+	SingleNeighbor      int `json:"single_neighbor"`
+	MostNeighbors       struct {
+		Count int      `json:"count"`
+		Nodes []string `json:"nodes"`
+	} `json:"most_neighbors"`
+}
+
+// Path answers /paths.
+type Path struct {
+	From         string   `json:"from"`
+	To           string   `json:"to"`
+	Found        bool     `json:"found"`
+	Length       int      `json:"length"`
+	Path         []string `json:"path"`
+	NodesVisited int      `json:"nodes_visited"`
+}
+
+// Reached is a node reached by a synonym or antonym path.
+type Reached struct {
+	Node     string   `json:"node"`
+	Distance int      `json:"distance"`
+	Path     []string `json:"path"`
+}
+
+func (s *Server) stats(r *http.Request) (any, error) {
+	return s.st.Stats(r.Context())
+}
+
+func (s *Server) degree(r *http.Request) (any, error) {
+	ctx := r.Context()
+	var d Degree
+	var err error
+	if d.WithoutSuccessors, err = graph.CountWithoutSuccessors(ctx, s.st); err != nil {
+		return nil, err
+	}
+	if d.WithoutPredecessors, err = graph.CountWithoutPredecessors(ctx, s.st); err != nil {
+		return nil, err
+	}
+	if d.SingleNeighbor, err = graph.CountSingleNeighbor(ctx, s.st); err != nil {
+		return nil, err
+	}
+	if d.MostNeighbors.Count, d.MostNeighbors.Nodes, err = graph.MostNeighbors(ctx, s.st); err != nil {
+		return nil, err
+	}
+	if d.MostNeighbors.Nodes == nil {
+		d.MostNeighbors.Nodes = []string{}
+	}
+	return d, nil
+}
+
+func (s *Server) node(r *http.Request) (any, error) {
+	name := r.PathValue("name")
+	labels, err := s.st.Labels(r.Context(), name)
+	if err != nil {
+		return nil, err
+	}
+	if len(labels) == 0 {
+		return nil, errorf(http.StatusNotFound, "no node %q", name)
+	}
+	return Node{Node: name, Labels: labels}, nil
+}
+
+// successors lists outgoing edges, optionally only from the sources in
+// ?source= and of the relations in ?relation=, which may repeat or hold a
+// comma-separated list. Pages come from the store, so the filters can leave
+// one short.
+func (s *Server) successors(r *http.Request) (any, error) {
+	p, err := s.storePage(r)
+	if err != nil {
+		return nil, err
+	}
+	rel, err := relations(r)
+	if err != nil {
+		return nil, err
+	}
+	edges, next, err := graph.SuccessorEdgesPage(r.Context(), s.st, r.PathValue("name"), sources(r), rel, p)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Edge, len(edges))
+	for i, e := range edges {
+		out[i] = edge(e.ToNode, e)
+	}
+	return storeItems(out, next), nil
+}
+
+func (s *Server) successorCount(r *http.Request) (any, error) {
+	rel, err := relations(r)
+	if err != nil {
+		return nil, err
+	}
+	count, _, err := graph.CountSuccessors(r.Context(), s.st, r.PathValue("name"), rel)
+	return Count{count}, err
+}
+
+// predecessors lists incoming edges, one item per edge, a store page at a
+// time.
+func (s *Server) predecessors(r *http.Request) (any, error) {
+	p, err := s.storePage(r)
+	if err != nil {
+		return nil, err
+	}
+	rel, err := relations(r)
+	if err != nil {
+		return nil, err
+	}
+	edges, next, err := s.st.PredecessorsPage(r.Context(), r.PathValue("name"), p)
+	if err != nil {
+		return nil, err
+	}
+	filter := sources(r)
+	var out []Edge
+	for _, e := range edges {
+		if graph.MatchesSource(e.Source, filter) && rel.Match(e.RelationType) {
+			out = append(out, edge(e.FromNode, e))
+		}
+	}
+	return storeItems(out, next), nil
+}
+
+func (s *Server) predecessorCount(r *http.Request) (any, error) {
+	rel, err := relations(r)
+	if err != nil {
+		return nil, err
+	}
+	count, err := graph.CountPredecessors(r.Context(), s.st, r.PathValue("name"), rel)
+	return Count{count}, err
+}
+
+// neighbors pages edges_bidirectional through the store. That table keeps
+// no relations, so with ?relation= the neighbours are worked out from the
+// edges in both directions and paged like the other computed lists.
+func (s *Server) neighbors(r *http.Request) (any, error) {
+	rel, err := relations(r)
+	if err != nil {
+		return nil, err
+	}
+	if !rel.All() {
+		return s.nodeList(r, func(ctx context.Context, node string, rel store.Relations) ([]string, error) {
+			neighbors, _, err := graph.Neighbors(ctx, s.st, node, rel)
+			return neighbors, err
+		})
+	}
+	p, err := s.storePage(r)
+	if err != nil {
+		return nil, err
+	}
+	neighbors, next, err := s.st.NeighborsPage(r.Context(), r.PathValue("name"), p)
+	if err != nil {
+		return nil, err
+	}
+	return storeItems(neighbors, next), nil
+}
+
+func (s *Server) neighborCount(r *http.Request) (any, error) {
+	rel, err := relations(r)
+	if err != nil {
+		return nil, err
+	}
+	neighbors, _, err := graph.Neighbors(r.Context(), s.st, r.PathValue("name"), rel)
+	return Count{len(neighbors)}, err
+}
+
+func (s *Server) grandchildren(r *http.Request) (any, error) {
+	return s.nodeList(r, func(ctx context.Context, node string, rel store.Relations) ([]string, error) {
+		grandchildren, _, err := graph.Grandchildren(ctx, s.st, node, rel)
+		return grandchildren, err
+	})
+}
+
+func (s *Server) grandparents(r *http.Request) (any, error) {
+	return s.nodeList(r, func(ctx context.Context, node string, rel store.Relations) ([]string, error) {
+		return graph.Grandparents(ctx, s.st, node, rel)
+	})
+}
+
+func (s *Server) similar(r *http.Request) (any, error) {
+	return s.nodeList(r, func(ctx context.Context, node string, _ store.Relations) ([]string, error) {
+		return graph.Similar(ctx, s.st, node)
+	})
+}
+
+// nodeList pages the node names fn returns for the {name} and ?relation=
+// of the request.
+func (s *Server) nodeList(r *http.Request, fn func(ctx context.Context, node string, rel store.Relations) ([]string, error)) (any, error) {
+	p, err := s.pageRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	rel, err := relations(r)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := fn(r.Context(), r.PathValue("name"), rel)
+	if err != nil {
+		return nil, err
+	}
+	return page(p, nodes), nil
+}
+
+func (s *Server) paths(r *http.Request) (any, error) {
+	from, to := r.URL.Query().Get("from"), r.URL.Query().Get("to")
+	if from == "" || to == "" {
+		return nil, errorf(http.StatusBadRequest, "both ?from= and ?to= are required")
+	}
+	path, length, visited, err := graph.ShortestPath(r.Context(), s.st, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if path == nil {
+		path = []string{}
+	}
+	return Path{From: from, To: to, Found: len(path) > 0, Length: length, Path: path, NodesVisited: visited}, nil
+}
+
+func (s *Server) synonyms(r *http.Request) (any, error) {
+	return s.reached(r, graph.DistantSynonyms)
+}
+
+func (s *Server) antonyms(r *http.Request) (any, error) {
+	return s.reached(r, graph.DistantAntonyms)
+}
+
+// reached pages the nodes a synonym or antonym path of ?distance= (2 when
+// left out) reaches.
+func (s *Server) reached(r *http.Request, fn func(ctx context.Context, st store.GraphStore, node string, distance int) ([]graph.PathResult, error)) (any, error) {
+	p, err := s.pageRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	distance := 2
+	if v := r.URL.Query().Get("distance"); v != "" {
+		distance, err = strconv.Atoi(v)
+		if err != nil || distance < 1 {
+			return nil, errorf(http.StatusBadRequest, "distance must be a positive number, got %q", v)
+		}
+	}
+	results, err := fn(r.Context(), s.st, r.PathValue("name"), distance)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Reached, len(results))
+	for i, res := range results {
+		out[i] = Reached{Node: res.Node, Distance: distance, Path: res.Path}
+	}
+	return page(p, out), nil
+}
+
+// rename moves a node to the name in the body, {"new_name": "..."}.
+func (s *Server) rename(r *http.Request) (any, error) {
+	if !s.opts.Writable {
+		return nil, errorf(http.StatusForbidden, "renaming changes the graph, start the server with --writable")
+	}
+	var body struct {
+		NewName string `json:"new_name"`
+	}
+	if err := json.NewDecoder(http.MaxBytesReader(nil, r.Body, 64*1024)).Decode(&body); err != nil {
+		return nil, errorf(http.StatusBadRequest, "bad body, want {\"new_name\": \"...\"}: %v", err)
+	}
+	if body.NewName == "" {
+		return nil, errorf(http.StatusBadRequest, "new_name is required")
+	}
+	oldName := r.PathValue("name")
+	if err := s.st.RenameNode(r.Context(), oldName, body.NewName); err != nil {
+		return nil, err
+	}
+	return map[string]string{"old_name": oldName, "new_name": body.NewName}, nil
+}
+
+// sources reads ?source=, repeated or comma-separated.
+func sources(r *http.Request) []string {
+	return list(r, "source")
+}
+
+// relations reads ?relation= the way --relation reads its patterns: /r/IsA,
+// a prefix such as /r/*, or -/r/Synonym to leave a relation out.
+func relations(r *http.Request) (store.Relations, error) {
+	return parseRelations(list(r, "relation"))
+}
+
+func parseRelations(patterns []string) (store.Relations, error) {
+	rel, err := store.ParseRelations(patterns)
+	if err != nil {
+		return rel, errorf(http.StatusBadRequest, "%v", err)
+	}
+	return rel, nil
+}
+
+// list reads a query parameter that may repeat or hold a comma-separated
+// list.
+func list(r *http.Request, name string) []string {
+	var out []string
+	for _, v := range r.URL.Query()[name] {
+		for _, s := range strings.Split(v, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				out = append(out, s)
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,104 @@
+package server
+
+import (
+	"encoding/base64"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/DavidZayar/cli/store"
+)
+
+// Page is one page of a list endpoint. NextCursor is empty on the last
+// page; otherwise pass it back as ?cursor= for the next one. Total is only
+// known for lists computed whole; the ones read from the store a page at a
+// time leave it out.
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	Total      *int   `json:"total,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// pageRequest is the ?limit= and ?cursor= of a request.
+type pageRequest struct {
+	limit  int
+	offset int
+}
+
+// limit reads ?limit=, bounded by the options.
+func (s *Server) limit(r *http.Request) (int, error) {
+	v := r.URL.Query().Get("limit")
+	if v == "" {
+		return s.opts.DefaultLimit, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 1 {
+		return 0, errorf(http.StatusBadRequest, "limit must be a positive number, got %q", v)
+	}
+	return min(n, s.opts.MaxLimit), nil
+}
+
+// storePage reads ?limit= and ?cursor= for a list the store pages itself;
+// the cursor is the store's.
+func (s *Server) storePage(r *http.Request) (store.Page, error) {
+	limit, err := s.limit(r)
+	return store.Page{Size: limit, Cursor: r.URL.Query().Get("cursor")}, err
+}
+
+func (s *Server) pageRequest(r *http.Request) (pageRequest, error) {
+	limit, err := s.limit(r)
+	p := pageRequest{limit: limit}
+	if err != nil {
+		return p, err
+	}
+	if v := r.URL.Query().Get("cursor"); v != "" {
+		offset, ok := decodeCursor(v)
+		if !ok {
+			return p, errorf(http.StatusBadRequest, "bad cursor %q", v)
+		}
+		p.offset = offset
+	}
+	return p, nil
+}
+
+// storeItems makes a page of a list the store paged.
+func storeItems[T any](items []T, next string) Page[T] {
+	if items == nil {
+		items = []T{}
+	}
+	return Page[T]{Items: items, NextCursor: next}
+}
+
+// page cuts the requested page out of items.
+func page[T any](p pageRequest, items []T) Page[T] {
+	total := len(items)
+	out := Page[T]{Items: []T{}, Total: &total}
+	if p.offset >= len(items) {
+		return out
+	}
+	end := min(p.offset+p.limit, len(items))
+	out.Items = items[p.offset:end]
+	if end < len(items) {
+		out.NextCursor = encodeCursor(end)
+	}
+	return out
+}
+
+// Cursors are opaque to clients, so what they hold can change without
+// breaking them.
+func encodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte("o:" + strconv.Itoa(offset)))
+}
+
+func decodeCursor(cursor string) (int, bool) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, false
+	}
+	v, ok := strings.CutPrefix(string(raw), "o:")
+	if !ok {
+		return 0, false
+	}
+	offset, err := strconv.Atoi(v)
+	return offset, err == nil && offset >= 0
+}
@@ -0,0 +1,437 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/DavidZaya21/parser/model"
+	"github.com/DavidZayar/cli/graph"
+	"github.com/DavidZayar/cli/graphrpc"
+	"github.com/DavidZayar/cli/store"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// frontierChunk caps the nodes sent in one Frontier message.
+const frontierChunk = 1000
+
+// maxFrontierDepth caps FrontierRequest.MaxDepth: past a few hops the
+// levels cover most of the graph.
+const maxFrontierDepth = 6
+
+// RPC answers the Graph gRPC service over one store. Streams send rows as
+// the store reads them and end when the client cancels or its deadline
+// passes; unary calls without a deadline get Options.Timeout.
+type RPC struct {
+	graphrpc.UnimplementedGraphServer
+	st   store.GraphStore
+	opts Options
+}
+
+// NewGRPC returns a gRPC server with the Graph service over st, logging
+// every call and turning store errors into gRPC status codes.
+func NewGRPC(st store.GraphStore, opts Options) *grpc.Server {
+	if opts.Timeout <= 0 {
+		opts.Timeout = 30 * time.Second
+	}
+	if opts.DefaultLimit <= 0 {
+		opts.DefaultLimit = 100
+	}
+	if opts.MaxLimit <= 0 {
+		opts.MaxLimit = 1000
+	}
+	rpc := &RPC{st: st, opts: opts}
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(rpc.unaryInterceptor),
+		grpc.ChainStreamInterceptor(rpc.streamInterceptor),
+	)
+	graphrpc.RegisterGraphServer(s, rpc)
+	return s
+}
+
+func (s *RPC) unaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.opts.Timeout)
+		defer cancel()
+	}
+	resp, err := handler(ctx, req)
+	err = rpcError(info.FullMethod, err)
+	log.Printf("RPC %s %s %s", info.FullMethod, status.Code(err), time.Since(start).Round(time.Microsecond))
+	return resp, err
+}
+
+func (s *RPC) streamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := rpcError(info.FullMethod, handler(srv, ss))
+	log.Printf("RPC %s %s %s", info.FullMethod, status.Code(err), time.Since(start).Round(time.Microsecond))
+	return err
+}
+
+// rpcError gives err the status code that says what went wrong, as
+// writeError does for HTTP.
+func rpcError(method string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	var e *Error
+	switch {
+	case errors.As(err, &e):
+		code := codes.Unknown
+		switch e.Status {
+		case http.StatusBadRequest:
+			code = codes.InvalidArgument
+		case http.StatusForbidden:
+			code = codes.PermissionDenied
+		case http.StatusNotFound:
+			code = codes.NotFound
+		}
+		return status.Error(code, e.Message)
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, "the query took longer than the deadline")
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, "the call was cancelled")
+	case errors.Is(err, store.ErrNodeNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, store.ErrBadCursor):
+		return status.Error(codes.InvalidArgument, "bad cursor, pass next_cursor back unchanged with the same node")
+	default:
+		log.Printf("❌ %s: %v", method, err)
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+func rpcEdge(e model.Edge) *graphrpc.Edge {
+	return &graphrpc.Edge{
+		From:          e.FromNode,
+		To:            e.ToNode,
+		Relation:      e.RelationType,
+		RelationLabel: e.RelationLabel,
+		Source:        e.Source,
+		Sentence:      e.Sentence,
+	}
+}
+
+func needNode(node string) error {
+	if node == "" {
+		return errorf(http.StatusBadRequest, "node is required")
+	}
+	return nil
+}
+
+// nodeRequest checks a NodeRequest and reads its relation filter.
+func nodeRequest(in *graphrpc.NodeRequest) (store.Relations, error) {
+	if err := needNode(in.Node); err != nil {
+		return store.Relations{}, err
+	}
+	return parseRelations(in.Relations)
+}
+
+func (s *RPC) Node(ctx context.Context, in *graphrpc.NodeRequest) (*graphrpc.Node, error) {
+	if err := needNode(in.Node); err != nil {
+		return nil, err
+	}
+	labels, err := s.st.Labels(ctx, in.Node)
+	if err != nil {
+		return nil, err
+	}
+	if len(labels) == 0 {
+		return nil, errorf(http.StatusNotFound, "no node %q", in.Node)
+	}
+	return &graphrpc.Node{Node: in.Node, Labels: labels}, nil
+}
+
+// Successors sends each outgoing edge as the store reads it, so a hub
+// node's edges are never held at once.
+func (s *RPC) Successors(in *graphrpc.NodeRequest, stream grpc.ServerStreamingServer[graphrpc.Edge]) error {
+	rel, err := nodeRequest(in)
+	if err != nil {
+		return err
+	}
+	return s.st.EachSuccessor(stream.Context(), in.Node, rel, sendEdge(stream, in.Sources))
+}
+
+func (s *RPC) CountSuccessors(ctx context.Context, in *graphrpc.NodeRequest) (*graphrpc.Count, error) {
+	rel, err := nodeRequest(in)
+	if err != nil {
+		return nil, err
+	}
+	count, _, err := graph.CountSuccessors(ctx, s.st, in.Node, rel)
+	return &graphrpc.Count{Count: int64(count)}, err
+}
+
+// Predecessors is Successors for the incoming edges.
+func (s *RPC) Predecessors(in *graphrpc.NodeRequest, stream grpc.ServerStreamingServer[graphrpc.Edge]) error {
+	rel, err := nodeRequest(in)
+	if err != nil {
+		return err
+	}
+	return s.st.EachPredecessor(stream.Context(), in.Node, rel, sendEdge(stream, in.Sources))
+}
+
+// sendEdge sends the edges that pass the source filter down stream.
+func sendEdge(stream grpc.ServerStreamingServer[graphrpc.Edge], sources []string) func(e model.Edge) error {
+	return func(e model.Edge) error {
+		if !graph.MatchesSource(e.Source, sources) {
+			return nil
+		}
+		return stream.Send(rpcEdge(e))
+	}
+}
+
+func (s *RPC) CountPredecessors(ctx context.Context, in *graphrpc.NodeRequest) (*graphrpc.Count, error) {
+	rel, err := nodeRequest(in)
+	if err != nil {
+		return nil, err
+	}
+	count, err := graph.CountPredecessors(ctx, s.st, in.Node, rel)
+	return &graphrpc.Count{Count: int64(count)}, err
+}
+
+func (s *RPC) Neighbors(ctx context.Context, in *graphrpc.NodeRequest) (*graphrpc.NodeList, error) {
+	return nodeList(in, func(rel store.Relations) ([]string, error) {
+		neighbors, _, err := graph.Neighbors(ctx, s.st, in.Node, rel)
+		return neighbors, err
+	})
+}
+
+func (s *RPC) SuccessorsPage(ctx context.Context, in *graphrpc.PageRequest) (*graphrpc.EdgePage, error) {
+	p, rel, err := s.page(in)
+	if err != nil {
+		return nil, err
+	}
+	edges, next, err := graph.SuccessorEdgesPage(ctx, s.st, in.Node, in.Sources, rel, p)
+	if err != nil {
+		return nil, err
+	}
+	return edgePage(edges, next), nil
+}
+
+func (s *RPC) PredecessorsPage(ctx context.Context, in *graphrpc.PageRequest) (*graphrpc.EdgePage, error) {
+	p, rel, err := s.page(in)
+	if err != nil {
+		return nil, err
+	}
+	all, next, err := s.st.PredecessorsPage(ctx, in.Node, p)
+	if err != nil {
+		return nil, err
+	}
+	var edges []model.Edge
+	for _, e := range all {
+		if graph.MatchesSource(e.Source, in.Sources) && rel.Match(e.RelationType) {
+			edges = append(edges, e)
+		}
+	}
+	return edgePage(edges, next), nil
+}
+
+// NeighborsPage reads edges_bidirectional, which does not keep relations,
+// so it takes no relation filter; Neighbors does.
+func (s *RPC) NeighborsPage(ctx context.Context, in *graphrpc.PageRequest) (*graphrpc.NodePage, error) {
+	p, rel, err := s.page(in)
+	if err != nil {
+		return nil, err
+	}
+	if !rel.All() {
+		return nil, errorf(http.StatusBadRequest, "NeighborsPage cannot filter by relation, use Neighbors")
+	}
+	neighbors, next, err := s.st.NeighborsPage(ctx, in.Node, p)
+	if err != nil {
+		return nil, err
+	}
+	if neighbors == nil {
+		neighbors = []string{}
+	}
+	return &graphrpc.NodePage{Nodes: neighbors, NextCursor: next}, nil
+}
+
+// page checks a PageRequest: the page size defaults like ?limit= does and
+// is capped by the same maximum.
+func (s *RPC) page(in *graphrpc.PageRequest) (store.Page, store.Relations, error) {
+	if err := needNode(in.Node); err != nil {
+		return store.Page{}, store.Relations{}, err
+	}
+	if in.PageSize < 0 {
+		return store.Page{}, store.Relations{}, errorf(http.StatusBadRequest, "page_size must not be negative, got %d", in.PageSize)
+	}
+	rel, err := parseRelations(in.Relations)
+	if err != nil {
+		return store.Page{}, store.Relations{}, err
+	}
+	size := int(in.PageSize)
+	if size == 0 {
+		size = s.opts.DefaultLimit
+	}
+	return store.Page{Size: min(size, s.opts.MaxLimit), Cursor: in.Cursor}, rel, nil
+}
+
+func edgePage(edges []model.Edge, next string) *graphrpc.EdgePage {
+	out := &graphrpc.EdgePage{Edges: make([]*graphrpc.Edge, len(edges)), NextCursor: next}
+	for i, e := range edges {
+		out.Edges[i] = rpcEdge(e)
+	}
+	return out
+}
+
+func (s *RPC) Grandchildren(ctx context.Context, in *graphrpc.NodeRequest) (*graphrpc.NodeList, error) {
+	return nodeList(in, func(rel store.Relations) ([]string, error) {
+		grandchildren, _, err := graph.Grandchildren(ctx, s.st, in.Node, rel)
+		return grandchildren, err
+	})
+}
+
+func (s *RPC) Grandparents(ctx context.Context, in *graphrpc.NodeRequest) (*graphrpc.NodeList, error) {
+	return nodeList(in, func(rel store.Relations) ([]string, error) {
+		return graph.Grandparents(ctx, s.st, in.Node, rel)
+	})
+}
+
+func nodeList(in *graphrpc.NodeRequest, fn func(rel store.Relations) ([]string, error)) (*graphrpc.NodeList, error) {
+	rel, err := nodeRequest(in)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := fn(rel)
+	if err != nil {
+		return nil, err
+	}
+	return &graphrpc.NodeList{Nodes: nodes}, nil
+}
+
+func (s *RPC) Similar(in *graphrpc.NodeRequest, stream grpc.ServerStreamingServer[graphrpc.NodeName]) error {
+	if err := needNode(in.Node); err != nil {
+		return err
+	}
+	return graph.EachSimilar(stream.Context(), s.st, in.Node, func(name string) error {
+		return stream.Send(&graphrpc.NodeName{Node: name})
+	})
+}
+
+func (s *RPC) ShortestPath(ctx context.Context, in *graphrpc.PathRequest) (*graphrpc.Path, error) {
+	if in.From == "" || in.To == "" {
+		return nil, errorf(http.StatusBadRequest, "both from and to are required")
+	}
+	path, length, visited, err := graph.ShortestPath(ctx, s.st, in.From, in.To)
+	if err != nil {
+		return nil, err
+	}
+	return &graphrpc.Path{Found: len(path) > 0, Length: int32(length), Path: path, NodesVisited: int64(visited)}, nil
+}
+
+// Frontiers sends each breadth-first level once it is complete, cut into
+// messages of at most frontierChunk nodes.
+func (s *RPC) Frontiers(in *graphrpc.FrontierRequest, stream grpc.ServerStreamingServer[graphrpc.Frontier]) error {
+	if err := needNode(in.Node); err != nil {
+		return err
+	}
+	if in.MaxDepth < 1 || in.MaxDepth > maxFrontierDepth {
+		return errorf(http.StatusBadRequest, "max_depth must be between 1 and %d, got %d", maxFrontierDepth, in.MaxDepth)
+	}
+	return graph.EachFrontier(stream.Context(), s.st, in.Node, int(in.MaxDepth), func(depth int, frontier []string) error {
+		for len(frontier) > 0 {
+			n := min(len(frontier), frontierChunk)
+			if err := stream.Send(&graphrpc.Frontier{Depth: int32(depth), Nodes: frontier[:n]}); err != nil {
+				return err
+			}
+			frontier = frontier[n:]
+		}
+		return nil
+	})
+}
+
+func (s *RPC) Synonyms(ctx context.Context, in *graphrpc.DistanceRequest) (*graphrpc.ReachedList, error) {
+	return s.reached(ctx, in, graph.DistantSynonyms)
+}
+
+func (s *RPC) Antonyms(ctx context.Context, in *graphrpc.DistanceRequest) (*graphrpc.ReachedList, error) {
+	return s.reached(ctx, in, graph.DistantAntonyms)
+}
+
+func (s *RPC) reached(ctx context.Context, in *graphrpc.DistanceRequest, fn func(ctx context.Context, st store.GraphStore, node string, distance int) ([]graph.PathResult, error)) (*graphrpc.ReachedList, error) {
+	if err := needNode(in.Node); err != nil {
+		return nil, err
+	}
+	if in.Distance < 1 {
+		return nil, errorf(http.StatusBadRequest, "distance must be a positive number, got %d", in.Distance)
+	}
+	results, err := fn(ctx, s.st, in.Node, int(in.Distance))
+	if err != nil {
+		return nil, err
+	}
+	out := &graphrpc.ReachedList{Reached: make([]*graphrpc.Reached, len(results))}
+	for i, r := range results {
+		out.Reached[i] = &graphrpc.Reached{Node: r.Node, Path: r.Path}
+	}
+	return out, nil
+}
+
+func (s *RPC) Stats(ctx context.Context, _ *graphrpc.StatsRequest) (*graphrpc.Stats, error) {
+	stats, err := s.st.Stats(ctx)
+	return &graphrpc.Stats{Nodes: stats.Nodes, Edges: stats.Edges}, err
+}
+
+func (s *RPC) Degree(ctx context.Context, _ *graphrpc.StatsRequest) (*graphrpc.Degree, error) {
+	withoutSucc, err := graph.CountWithoutSuccessors(ctx, s.st)
+	if err != nil {
+		return nil, err
+	}
+	withoutPred, err := graph.CountWithoutPredecessors(ctx, s.st)
+	if err != nil {
+		return nil, err
+	}
+	single, err := graph.CountSingleNeighbor(ctx, s.st)
+	if err != nil {
+		return nil, err
+	}
+	most, mostNodes, err := graph.MostNeighbors(ctx, s.st)
+	if err != nil {
+		return nil, err
+	}
+	return &graphrpc.Degree{
+		WithoutSuccessors:   int64(withoutSucc),
+		WithoutPredecessors: int64(withoutPred),
+		SingleNeighbor:      int64(single),
+		MostNeighbors:       int64(most),
+		MostNeighborsNodes:  mostNodes,
+	}, nil
+}
+
+func (s *RPC) ScanNodes(in *graphrpc.ScanRequest, stream grpc.ServerStreamingServer[graphrpc.NodeName]) error {
+	return s.st.Nodes(stream.Context(), func(name string) error {
+		if !strings.HasPrefix(name, in.Prefix) {
+			return nil
+		}
+		return stream.Send(&graphrpc.NodeName{Node: name})
+	})
+}
+
+func (s *RPC) ScanEdges(in *graphrpc.ScanRequest, stream grpc.ServerStreamingServer[graphrpc.Edge]) error {
+	return s.st.Edges(stream.Context(), func(e model.Edge) error {
+		if !strings.HasPrefix(e.FromNode, in.Prefix) || !graph.MatchesSource(e.Source, in.Sources) {
+			return nil
+		}
+		return stream.Send(rpcEdge(e))
+	})
+}
+
+func (s *RPC) Rename(ctx context.Context, in *graphrpc.RenameRequest) (*graphrpc.RenameReply, error) {
+	if !s.opts.Writable {
+		return nil, errorf(http.StatusForbidden, "renaming changes the graph, start the server with --writable")
+	}
+	if in.OldName == "" || in.NewName == "" {
+		return nil, errorf(http.StatusBadRequest, "old_name and new_name are required")
+	}
+	if err := s.st.RenameNode(ctx, in.OldName, in.NewName); err != nil {
+		return nil, err
+	}
+	return &graphrpc.RenameReply{OldName: in.OldName, NewName: in.NewName}, nil
+}
@@ -0,0 +1,166 @@
+// Package server answers the dbcli queries over HTTP with JSON, and over
+// gRPC as the graphrpc Graph service. It only needs a store.GraphStore, so
+// the same handlers run against the shared Cassandra session in production
+// and against store.NewMemory in tests.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/DavidZayar/cli/store"
+)
+
+// Options tune a Server. Zero values get the defaults below.
+type Options struct {
+	// Timeout bounds the work done for one request, and for a unary gRPC
+	// call that brings no deadline of its own.
+	Timeout time.Duration
+	// DefaultLimit and MaxLimit bound the page size of list endpoints.
+	DefaultLimit int
+	MaxLimit     int
+	// Writable allows POST /nodes/{name}/rename and the Rename call.
+	Writable bool
+}
+
+// Server is an http.Handler over one store.
+type Server struct {
+	st   store.GraphStore
+	opts Options
+	mux  *http.ServeMux
+}
+
+// New returns a server over st. Node names hold slashes, so clients escape
+// them in paths: /nodes/%2Fc%2Fen%2Fjar/successors.
+func New(st store.GraphStore, opts Options) *Server {
+	if opts.Timeout <= 0 {
+		opts.Timeout = 30 * time.Second
+	}
+	if opts.DefaultLimit <= 0 {
+		opts.DefaultLimit = 100
+	}
+	if opts.MaxLimit <= 0 {
+		opts.MaxLimit = 1000
+	}
+	s := &Server{st: st, opts: opts, mux: http.NewServeMux()}
+	s.routes()
+	return s
+}
+
+func (s *Server) routes() {
+	s.handle("GET /healthz", func(r *http.Request) (any, error) {
+		return map[string]string{"status": "ok"}, nil
+	})
+	s.handle("GET /stats", s.stats)
+	s.handle("GET /stats/degree", s.degree)
+	s.handle("GET /nodes/{name}", s.node)
+	s.handle("GET /nodes/{name}/successors", s.successors)
+	s.handle("GET /nodes/{name}/successors/count", s.successorCount)
+	s.handle("GET /nodes/{name}/predecessors", s.predecessors)
+	s.handle("GET /nodes/{name}/predecessors/count", s.predecessorCount)
+	s.handle("GET /nodes/{name}/neighbors", s.neighbors)
+	s.handle("GET /nodes/{name}/neighbors/count", s.neighborCount)
+	s.handle("GET /nodes/{name}/grandchildren", s.grandchildren)
+	s.handle("GET /nodes/{name}/grandparents", s.grandparents)
+	s.handle("GET /nodes/{name}/synonyms", s.synonyms)
+	s.handle("GET /nodes/{name}/antonyms", s.antonyms)
+	s.handle("POST /nodes/{name}/rename", s.rename)
+	s.handle("GET /similar/{name}", s.similar)
+	s.handle("GET /paths", s.paths)
+	s.handle("/", func(r *http.Request) (any, error) {
+		return nil, errorf(http.StatusNotFound, "no endpoint %s %s", r.Method, r.URL.Path)
+	})
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// handle registers fn, which returns the value to send as JSON or an error
+// to send as a JSON error. It runs under the request timeout.
+func (s *Server) handle(pattern string, fn func(r *http.Request) (any, error)) {
+	s.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), s.opts.Timeout)
+		defer cancel()
+		v, err := fn(r.WithContext(ctx))
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, v)
+	})
+}
+
+// Error is an error with the HTTP status to answer it with.
+type Error struct {
+	Status  int
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func errorf(status int, format string, a ...any) error {
+	return &Error{Status: status, Message: fmt.Sprintf(format, a...)}
+}
+
+// writeError answers with {"error": {"status": ..., "message": ...}}.
+// Errors that are not an *Error are internal, unless the request ran out of
+// time or the node did not exist.
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	var e *Error
+	switch {
+	case errors.As(err, &e):
+	case errors.Is(err, context.DeadlineExceeded):
+		e = &Error{Status: http.StatusGatewayTimeout, Message: "the query took longer than the request timeout"}
+	case errors.Is(err, context.Canceled) && r.Context().Err() != nil:
+		// The client went away; nobody is left to answer.
+		return
+	case errors.Is(err, store.ErrNodeNotFound):
+		e = &Error{Status: http.StatusNotFound, Message: err.Error()}
+	case errors.Is(err, store.ErrBadCursor):
+		e = &Error{Status: http.StatusBadRequest, Message: "bad cursor, pass next_cursor back unchanged with the same node"}
+	default:
+		log.Printf("❌ %s %s: %v", r.Method, r.URL.Path, err)
+		e = &Error{Status: http.StatusInternalServerError, Message: err.Error()}
+	}
+	writeJSON(w, e.Status, map[string]any{
+		"error": map[string]any{"status": e.Status, "message": e.Message},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		log.Printf("⚠️ Failed to write response: %v", err)
+	}
+}
+
+// Logged logs one line per request: method, path, status and time taken.
+func Logged(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		h.ServeHTTP(rec, r)
+		log.Printf("%s %s %d %s", r.Method, r.URL.RequestURI(), rec.status, time.Since(start).Round(time.Microsecond))
+	})
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
@@ -0,0 +1,262 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/DavidZayar/cli/store"
+)
+
+// newTestServer serves testdata/sample.tsv from the in-memory store.
+func newTestServer(t *testing.T, opts Options) *httptest.Server {
+	t.Helper()
+	st, err := store.LoadTSV(context.Background(), "../testdata/sample.tsv")
+	if err != nil {
+		t.Fatalf("load sample: %v", err)
+	}
+	srv := httptest.NewServer(New(st, opts))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// nodePath escapes node into a path segment, as clients must for names
+// holding slashes.
+func nodePath(node string, rest ...string) string {
+	return "/nodes/" + url.PathEscape(node) + strings.Join(rest, "")
+}
+
+// do sends a request and decodes the JSON answer into v, returning the
+// status.
+func do(t *testing.T, srv *httptest.Server, method, path, body string, v any) int {
+	t.Helper()
+	req, err := http.NewRequest(method, srv.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("%s %s: Content-Type %q", method, path, ct)
+	}
+	if v != nil {
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			t.Fatalf("%s %s: decode: %v", method, path, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+type errorBody struct {
+	Error struct {
+		Status  int    `json:"status"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func TestErrors(t *testing.T) {
+	srv := newTestServer(t, Options{MaxLimit: 10})
+	tests := []struct {
+		name    string
+		method  string
+		path    string
+		body    string
+		status  int
+		message string
+	}{
+		{name: "unknown endpoint", method: "GET", path: "/nowhere", status: 404, message: "no endpoint GET /nowhere"},
+		{name: "unknown node", method: "GET", path: nodePath("/c/en/nowhere"), status: 404, message: `no node "/c/en/nowhere"`},
+		{name: "unescaped slashes", method: "GET", path: "/nodes/c/en/jar", status: 404, message: "no endpoint"},
+		{name: "zero limit", method: "GET", path: nodePath("/c/en/jar", "/successors?limit=0"), status: 400, message: "limit must be a positive number"},
+		{name: "bad limit", method: "GET", path: nodePath("/c/en/jar", "/grandchildren?limit=ten"), status: 400, message: `got "ten"`},
+		{name: "bad store cursor", method: "GET", path: nodePath("/c/en/jar", "/successors?cursor=nonsense"), status: 400, message: "bad cursor"},
+		{name: "bad list cursor", method: "GET", path: nodePath("/c/en/jar", "/grandchildren?cursor=nonsense"), status: 400, message: `bad cursor "nonsense"`},
+		{name: "bad relation", method: "GET", path: nodePath("/c/en/jar", "/successors?relation=/r/*IsA"), status: 400, message: "'*' can only end a prefix"},
+		{name: "bad distance", method: "GET", path: nodePath("/c/en/jar", "/synonyms?distance=0"), status: 400, message: "distance must be a positive number"},
+		{name: "path without to", method: "GET", path: "/paths?from=%2Fc%2Fen%2Fjar", status: 400, message: "both ?from= and ?to= are required"},
+		{name: "rename read-only", method: "POST", path: nodePath("/c/en/jar", "/rename"), body: `{"new_name": "/c/en/pot"}`, status: 403, message: "--writable"},
+		{name: "wrong method", method: "DELETE", path: nodePath("/c/en/jar"), status: 404, message: "no endpoint DELETE"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body errorBody
+			status := do(t, srv, tt.method, tt.path, tt.body, &body)
+			if status != tt.status || body.Error.Status != tt.status {
+				t.Errorf("status %d, body status %d, want %d", status, body.Error.Status, tt.status)
+			}
+			if !strings.Contains(body.Error.Message, tt.message) {
+				t.Errorf("message %q does not contain %q", body.Error.Message, tt.message)
+			}
+		})
+	}
+}
+
+func TestRename(t *testing.T) {
+	srv := newTestServer(t, Options{Writable: true})
+	tests := []struct {
+		name   string
+		node   string
+		body   string
+		status int
+	}{
+		{name: "bad body", node: "/c/en/jar", body: "jar", status: 400},
+		{name: "no new name", node: "/c/en/jar", body: `{}`, status: 400},
+		{name: "unknown node", node: "/c/en/nowhere", body: `{"new_name": "/c/en/somewhere"}`, status: 404},
+		{name: "renamed", node: "/c/en/jar", body: `{"new_name": "/c/en/pot"}`, status: 200},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if status := do(t, srv, "POST", nodePath(tt.node, "/rename"), tt.body, nil); status != tt.status {
+				t.Errorf("status %d, want %d", status, tt.status)
+			}
+		})
+	}
+
+	var node Node
+	if status := do(t, srv, "GET", nodePath("/c/en/pot"), "", &node); status != 200 || !slices.Equal(node.Labels, []string{"jar"}) {
+		t.Errorf("renamed node: status %d, labels %q", status, node.Labels)
+	}
+}
+
+func TestEscapedNames(t *testing.T) {
+	srv := newTestServer(t, Options{})
+
+	var node Node
+	if status := do(t, srv, "GET", "/nodes/%2Fc%2Fen%2Fcar", "", &node); status != 200 {
+		t.Fatalf("status %d", status)
+	}
+	if node.Node != "/c/en/car" || !slices.Equal(node.Labels, []string{"car", "automobile"}) {
+		t.Errorf("got %+v", node)
+	}
+
+	var count Count
+	if status := do(t, srv, "GET", "/nodes/%2Fc%2Fen%2Fjar/successors/count", "", &count); status != 200 || count.Count != 2 {
+		t.Errorf("successor count: status %d, count %d, want 2", status, count.Count)
+	}
+
+	var path Path
+	if status := do(t, srv, "GET", "/paths?from=%2Fc%2Fen%2Fsteam_locomotive&to=%2Fc%2Fen%2Ftransportation", "", &path); status != 200 {
+		t.Fatalf("path: status %d", status)
+	}
+	want := []string{"/c/en/steam_locomotive", "/c/en/locomotive", "/c/en/vehicle", "/c/en/transportation"}
+	if !path.Found || path.Length != 3 || !slices.Equal(path.Path, want) {
+		t.Errorf("path = %+v, want %q", path, want)
+	}
+}
+
+// TestCursorRoundTrip walks each list endpoint a page at a time, passing
+// next_cursor back, and checks the pages add up to the whole list.
+func TestCursorRoundTrip(t *testing.T) {
+	srv := newTestServer(t, Options{})
+	tests := []struct {
+		name  string
+		path  string
+		limit int
+		want  []string
+	}{
+		{
+			name:  "successors",
+			path:  nodePath("/c/en/jar", "/successors"),
+			limit: 1,
+			want:  []string{"/c/en/container", "/c/en/jar", "/c/en/kitchen"},
+		},
+		{
+			name:  "successors by relation",
+			path:  nodePath("/c/en/jar", "/successors?relation=-/r/RelatedTo"),
+			limit: 1,
+			want:  []string{"/c/en/container", "/c/en/kitchen"},
+		},
+		{
+			name:  "predecessors",
+			path:  nodePath("/c/en/vehicle", "/predecessors"),
+			limit: 1,
+			want:  []string{"/c/en/car", "/c/en/locomotive"},
+		},
+		{
+			name:  "neighbors",
+			path:  nodePath("/c/en/vehicle", "/neighbors"),
+			limit: 2,
+			want:  []string{"/c/en/car", "/c/en/locomotive", "/c/en/transportation"},
+		},
+		{
+			name:  "neighbors by relation",
+			path:  nodePath("/c/en/vehicle", "/neighbors?relation=/r/IsA"),
+			limit: 1,
+			want:  []string{"/c/en/car", "/c/en/locomotive"},
+		},
+		{
+			name:  "grandparents",
+			path:  nodePath("/c/en/transportation", "/grandparents"),
+			limit: 1,
+			want:  []string{"/c/en/car", "/c/en/locomotive"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			cursor := ""
+			for pages := 0; ; pages++ {
+				if pages > len(tt.want) {
+					t.Fatalf("still paging after %d pages", pages)
+				}
+				var page Page[json.RawMessage]
+				status := do(t, srv, "GET", withPage(tt.path, tt.limit, cursor), "", &page)
+				if status != 200 {
+					t.Fatalf("page %d: status %d", pages, status)
+				}
+				if len(page.Items) > tt.limit {
+					t.Errorf("page %d has %d items, limit %d", pages, len(page.Items), tt.limit)
+				}
+				for _, raw := range page.Items {
+					got = append(got, itemNode(t, raw))
+				}
+				if page.NextCursor == "" {
+					break
+				}
+				cursor = page.NextCursor
+			}
+			slices.Sort(got)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// withPage adds ?limit= and, past the first page, ?cursor= to path.
+func withPage(path string, limit int, cursor string) string {
+	q := url.Values{}
+	q.Set("limit", strconv.Itoa(limit))
+	if cursor != "" {
+		q.Set("cursor", cursor)
+	}
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+	return path + sep + q.Encode()
+}
+
+// itemNode returns the node of a list item: a bare name, or the node field
+// of an edge.
+func itemNode(t *testing.T, raw json.RawMessage) string {
+	t.Helper()
+	var name string
+	if err := json.Unmarshal(raw, &name); err == nil {
+		return name
+	}
+	var e Edge
+	if err := json.Unmarshal(raw, &e); err != nil {
+		t.Fatalf("item %s: %v", raw, err)
+	}
+	return e.Node
+}
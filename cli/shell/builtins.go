@@ -0,0 +1,87 @@
+package shell
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// Builtins lists the commands that work on the shell rather than the graph.
+func Builtins() []string {
+	return []string{"help", "vars", "let", "print", "unset", "exit", "quit"}
+}
+
+// builtin runs name if it is a builtin and reports whether it was one.
+func (s *Shell) builtin(name string, args []word) (bool, error) {
+	switch name {
+	case "help":
+		if len(args) == 1 {
+			v, ok := verbs[args[0].text]
+			if !ok {
+				return true, fmt.Errorf("no query %q", args[0].text)
+			}
+			s.say(color.FgWhite, "%s\n  %s", v.usage(args[0].text), v.help)
+			return true, nil
+		}
+		s.help()
+	case "vars":
+		for _, name := range s.Names() {
+			nodes, _ := s.lookup(name)
+			s.say(color.FgWhite, "$%-12s %s", name, plural(len(nodes), "node"))
+		}
+	case "let":
+		if len(args) != 1 || !variable.MatchString("$"+args[0].text) || strings.Contains(args[0].text, "[") || args[0].text == "_" {
+			return true, fmt.Errorf("usage: let <name>, to keep $_ as $name")
+		}
+		if s.last == nil {
+			return true, fmt.Errorf("no result to keep yet")
+		}
+		s.vars[args[0].text] = append([]string(nil), s.last...)
+		s.say(color.FgCyan, "$%s holds %s", args[0].text, plural(len(s.last), "node"))
+	case "print":
+		if len(args) > 1 {
+			return true, fmt.Errorf("usage: print [$name]")
+		}
+		arg := word{text: "$_"}
+		if len(args) == 1 {
+			arg = args[0]
+		}
+		nodes, err := s.expand(arg)
+		if err != nil {
+			return true, err
+		}
+		s.nodes(nodes, nil)
+	case "unset":
+		if len(args) != 1 {
+			return true, fmt.Errorf("usage: unset <name>")
+		}
+		delete(s.vars, strings.TrimPrefix(args[0].text, "$"))
+	case "exit", "quit":
+		return true, ErrExit
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+func (s *Shell) help() {
+	s.say(color.FgWhite, "Queries (a node may be a name, $var or $var[n]):")
+	for _, name := range Verbs() {
+		v := verbs[name]
+		s.say(color.FgWhite, "  %-36s %s", v.usage(name), v.help)
+	}
+	s.say(color.FgWhite, `
+Every query that returns nodes numbers them and keeps them in $_.
+A query that takes one node also takes $_ or another variable holding
+many and runs once per node.
+
+  let <name>        Keep $_ as $name
+  print [$name]     Show a variable again; it becomes $_
+  vars              List the variables
+  unset <name>      Forget a variable
+  help [query]      This help, or one query's
+  exit, quit        Leave (or Ctrl-D)
+
+Names with spaces or quotes are written as Go strings: "/c/en/ice cream".`)
+}
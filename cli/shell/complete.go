@@ -0,0 +1,117 @@
+package shell
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Completer completes the word before the cursor: commands first, then
+// variables after $, and node names, which come from the variables and from
+// a prefix lookup in the store. Names with spaces or quotes complete after
+// an opening double quote.
+type Completer struct {
+	Shell *Shell
+	// Timeout bounds each lookup. Cassandra cannot look names up by prefix
+	// and scans for them, so a short timeout returns what was found in time.
+	Timeout time.Duration
+	// Limit caps the names offered at once.
+	Limit int
+}
+
+// Do implements readline.AutoCompleter: it returns what could follow the
+// word being typed, and the word's length.
+func (c *Completer) Do(line []rune, pos int) ([][]rune, int) {
+	before := string(line[:pos])
+	start := wordStart(before)
+	typed := before[start:]
+	first := strings.TrimSpace(before[:start]) == ""
+
+	var candidates []string
+	switch {
+	case first:
+		for _, name := range append(Builtins(), Verbs()...) {
+			if strings.HasPrefix(name, typed) {
+				candidates = append(candidates, name[len(typed):]+" ")
+			}
+		}
+	case strings.HasPrefix(typed, "$"):
+		for _, name := range c.Shell.Names() {
+			if strings.HasPrefix("$"+name, typed) {
+				candidates = append(candidates, ("$" + name)[len(typed):]+" ")
+			}
+		}
+	case strings.HasPrefix(typed, `"`):
+		prefix, err := strconv.Unquote(typed + `"`)
+		if err != nil {
+			break
+		}
+		for _, name := range c.names(prefix) {
+			rest := strconv.Quote(name[len(prefix):])
+			candidates = append(candidates, rest[1:])
+		}
+	default:
+		for _, name := range c.names(typed) {
+			if quote(name) == name {
+				candidates = append(candidates, name[len(typed):]+" ")
+			}
+		}
+	}
+
+	out := make([][]rune, len(candidates))
+	for i, cand := range candidates {
+		out[i] = []rune(cand)
+	}
+	return out, len([]rune(typed))
+}
+
+// names returns node names starting with prefix, remembered ones first.
+func (c *Completer) names(prefix string) []string {
+	seen := map[string]bool{}
+	var names []string
+	add := func(name string) {
+		if !seen[name] && strings.HasPrefix(name, prefix) && len(names) < c.Limit {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	for _, name := range c.Shell.Remembered() {
+		add(name)
+	}
+	if prefix == "" {
+		return names
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+	defer cancel()
+	found, _ := c.Shell.Store().NodesWithPrefix(ctx, prefix, c.Limit)
+	for _, name := range found {
+		add(name)
+	}
+	return names
+}
+
+// wordStart finds where the word before the cursor begins, treating an
+// unfinished quoted word as one word.
+func wordStart(before string) int {
+	start, inQuote, escaped := 0, false, false
+	for i, r := range before {
+		switch {
+		case escaped:
+			escaped = false
+		case inQuote && r == '\\':
+			escaped = true
+		case r == '"':
+			if !inQuote {
+				start = i
+			}
+			inQuote = !inQuote
+		case !inQuote && unicode.IsSpace(r):
+			start = i + utf8.RuneLen(r)
+		}
+	}
+	return start
+}
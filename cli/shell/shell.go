@@ -0,0 +1,176 @@
+// Package shell is dbcli's interactive mode: one store for the whole
+// session, the queries as verbs, and variables that hold earlier results so
+// the next query can start from them.
+package shell
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/DavidZayar/cli/store"
+	"github.com/fatih/color"
+)
+
+// ErrExit is returned by Exec for exit and quit.
+var ErrExit = errors.New("exit")
+
+// Shell runs lines against one store. It is not safe for concurrent use.
+type Shell struct {
+	st       store.GraphStore
+	out      io.Writer
+	writable bool
+
+	// last is the node set of the last query that returned nodes, $_.
+	last []string
+	vars map[string][]string
+}
+
+// New returns a shell over st that prints results to out. Renames need a
+// store opened for writing.
+func New(st store.GraphStore, out io.Writer, writable bool) *Shell {
+	return &Shell{st: st, out: out, writable: writable, vars: map[string][]string{}}
+}
+
+// Exec runs one line: a query verb or a builtin with its arguments.
+func (s *Shell) Exec(ctx context.Context, line string) error {
+	words, err := splitWords(line)
+	if err != nil || len(words) == 0 {
+		return err
+	}
+	name, args := words[0].text, words[1:]
+
+	if ok, err := s.builtin(name, args); ok {
+		return err
+	}
+	v, ok := verbs[name]
+	if !ok {
+		return fmt.Errorf("unknown command %q, try help", name)
+	}
+	if len(args) != len(v.args) {
+		return fmt.Errorf("usage: %s", v.usage(name))
+	}
+	expanded := make([][]string, len(args))
+	for i, arg := range args {
+		if expanded[i], err = s.expand(arg); err != nil {
+			return err
+		}
+		if len(expanded[i]) == 0 {
+			return fmt.Errorf("%s is empty", arg.text)
+		}
+		if len(expanded[i]) > 1 && !(len(v.args) == 1 && v.args[0] == "node") {
+			return fmt.Errorf("%s holds %d nodes; %s takes one, pick it with %s[1]", arg.text, len(expanded[i]), name, arg.text)
+		}
+	}
+	return v.run(ctx, s, expanded)
+}
+
+var variable = regexp.MustCompile(`^\$([A-Za-z_][A-Za-z0-9_]*)(?:\[([0-9]+)\])?$`)
+
+// expand turns a word into the nodes it stands for: itself, every node of
+// a variable ($_ is the last result), or one of them by position ($_[2]).
+func (s *Shell) expand(w word) ([]string, error) {
+	if w.quoted || !strings.HasPrefix(w.text, "$") {
+		return []string{w.text}, nil
+	}
+	m := variable.FindStringSubmatch(w.text)
+	if m == nil {
+		return nil, fmt.Errorf("bad variable %s, quote node names that start with $", w.text)
+	}
+	nodes, ok := s.lookup(m[1])
+	if !ok {
+		return nil, fmt.Errorf("no variable $%s", m[1])
+	}
+	if m[2] == "" {
+		return nodes, nil
+	}
+	i, _ := strconv.Atoi(m[2])
+	if i < 1 || i > len(nodes) {
+		return nil, fmt.Errorf("$%s has %d nodes, there is no [%d]", m[1], len(nodes), i)
+	}
+	return nodes[i-1 : i], nil
+}
+
+func (s *Shell) lookup(name string) ([]string, bool) {
+	if name == "_" {
+		return s.last, s.last != nil
+	}
+	nodes, ok := s.vars[name]
+	return nodes, ok
+}
+
+// Names returns the names of the variables, $_ first when it is set.
+func (s *Shell) Names() []string {
+	var names []string
+	for name := range s.vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if s.last != nil {
+		names = append([]string{"_"}, names...)
+	}
+	return names
+}
+
+// Remembered returns every node held by a variable, for completion.
+func (s *Shell) Remembered() []string {
+	seen := map[string]bool{}
+	var nodes []string
+	for _, name := range s.Names() {
+		vals, _ := s.lookup(name)
+		for _, n := range vals {
+			if !seen[n] {
+				seen[n] = true
+				nodes = append(nodes, n)
+			}
+		}
+	}
+	return nodes
+}
+
+// Store returns the store the shell queries.
+func (s *Shell) Store() store.GraphStore {
+	return s.st
+}
+
+// nodes prints a result set, numbered so its members can be picked with
+// $_[n], each with an optional note, and makes it $_.
+func (s *Shell) nodes(nodes []string, note func(node string) string) {
+	if nodes == nil {
+		nodes = []string{}
+	}
+	s.last = nodes
+	width := len(strconv.Itoa(len(nodes)))
+	for i, n := range nodes {
+		line := fmt.Sprintf("[%*d] %s", width, i+1, quote(n))
+		if note != nil {
+			if extra := note(n); extra != "" {
+				line += "  " + color.New(color.Faint).Sprint(extra)
+			}
+		}
+		fmt.Fprintln(s.out, line)
+	}
+	s.say(color.FgCyan, "%s in $_", plural(len(nodes), "node"))
+}
+
+// count prints a single number. It leaves $_ alone, so a set can be counted
+// and then explored further.
+func (s *Shell) count(what string, n int) {
+	s.say(color.FgGreen, "%s: %d", what, n)
+}
+
+func (s *Shell) say(c color.Attribute, format string, a ...any) {
+	color.New(c).Fprintf(s.out, format+"\n", a...)
+}
+
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
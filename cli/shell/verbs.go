@@ -0,0 +1,284 @@
+package shell
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/DavidZayar/cli/graph"
+	"github.com/DavidZayar/cli/store"
+	"github.com/fatih/color"
+)
+
+// verb is a query run from the shell. A verb that takes one node also takes
+// a variable holding many and runs once per node: lists are merged in
+// order, counts are added up.
+type verb struct {
+	args []string
+	help string
+	run  func(ctx context.Context, s *Shell, args [][]string) error
+}
+
+func (v verb) usage(name string) string {
+	if len(v.args) == 0 {
+		return name
+	}
+	return name + " <" + strings.Join(v.args, "> <") + ">"
+}
+
+var verbs = map[string]verb{
+	"one": {args: []string{"node"}, help: "Successors of a node, with the relations to them",
+		run: func(ctx context.Context, s *Shell, args [][]string) error {
+			relations := map[string][]string{}
+			nodes, err := union(args[0], func(node string) ([]string, error) {
+				edges, _, err := graph.SuccessorEdges(ctx, s.st, node, nil, store.Relations{})
+				var to []string
+				for _, e := range edges {
+					relations[e.ToNode] = appendNew(relations[e.ToNode], e.RelationType)
+					to = append(to, e.ToNode)
+				}
+				return to, err
+			})
+			if err != nil {
+				return err
+			}
+			s.nodes(nodes, func(n string) string { return strings.Join(relations[n], ", ") })
+			return nil
+		}},
+	"two": {args: []string{"node"}, help: "Count successors",
+		run: func(ctx context.Context, s *Shell, args [][]string) error {
+			total, err := sum(args[0], func(node string) (int, error) {
+				count, _, err := graph.CountSuccessors(ctx, s.st, node, store.Relations{})
+				return count, err
+			})
+			if err == nil {
+				s.count("Successors", total)
+			}
+			return err
+		}},
+	"three": {args: []string{"node"}, help: "Predecessors of a node, with the relations from them",
+		run: func(ctx context.Context, s *Shell, args [][]string) error {
+			relations := map[string][]string{}
+			nodes, err := union(args[0], func(node string) ([]string, error) {
+				predecessors, err := graph.Predecessors(ctx, s.st, node, nil, store.Relations{})
+				var from []string
+				for _, p := range predecessors {
+					for _, e := range p.Edges {
+						relations[p.Node] = appendNew(relations[p.Node], e.RelationType)
+					}
+					from = append(from, p.Node)
+				}
+				return from, err
+			})
+			if err != nil {
+				return err
+			}
+			s.nodes(nodes, func(n string) string { return strings.Join(relations[n], ", ") })
+			return nil
+		}},
+	"four": {args: []string{"node"}, help: "Count predecessors",
+		run: func(ctx context.Context, s *Shell, args [][]string) error {
+			total, err := sum(args[0], func(node string) (int, error) {
+				return graph.CountPredecessors(ctx, s.st, node, store.Relations{})
+			})
+			if err == nil {
+				s.count("Predecessors", total)
+			}
+			return err
+		}},
+	"five": {args: []string{"node"}, help: "Neighbours in either direction",
+		run: func(ctx context.Context, s *Shell, args [][]string) error {
+			return s.list(args[0], func(node string) ([]string, error) {
+				neighbors, _, err := graph.Neighbors(ctx, s.st, node, store.Relations{})
+				return neighbors, err
+			})
+		}},
+	"six": {args: []string{"node"}, help: "Count neighbours",
+		run: func(ctx context.Context, s *Shell, args [][]string) error {
+			total, err := sum(args[0], func(node string) (int, error) {
+				neighbors, _, err := graph.Neighbors(ctx, s.st, node, store.Relations{})
+				return len(neighbors), err
+			})
+			if err == nil {
+				s.count("Neighbors", total)
+			}
+			return err
+		}},
+	"seven": {args: []string{"node"}, help: "Grandchildren",
+		run: func(ctx context.Context, s *Shell, args [][]string) error {
+			return s.list(args[0], func(node string) ([]string, error) {
+				grandchildren, _, err := graph.Grandchildren(ctx, s.st, node, store.Relations{})
+				return grandchildren, err
+			})
+		}},
+	"eight": {args: []string{"node"}, help: "Grandparents",
+		run: func(ctx context.Context, s *Shell, args [][]string) error {
+			return s.list(args[0], func(node string) ([]string, error) {
+				return graph.Grandparents(ctx, s.st, node, store.Relations{})
+			})
+		}},
+	"nine": {help: "Count all nodes",
+		run: func(ctx context.Context, s *Shell, args [][]string) error {
+			return s.scanCount(ctx, "Nodes", graph.CountNodes)
+		}},
+	"ten": {help: "Count nodes without successors",
+		run: func(ctx context.Context, s *Shell, args [][]string) error {
+			return s.scanCount(ctx, "Nodes without successors", graph.CountWithoutSuccessors)
+		}},
+	"eleven": {help: "Count nodes without predecessors",
+		run: func(ctx context.Context, s *Shell, args [][]string) error {
+			return s.scanCount(ctx, "Nodes without predecessors", graph.CountWithoutPredecessors)
+		}},
+	"twelve": {help: "Nodes with the most neighbours",
+		run: func(ctx context.Context, s *Shell, args [][]string) error {
+			most, nodes, err := graph.MostNeighbors(ctx, s.st)
+			if err != nil {
+				return err
+			}
+			s.nodes(nodes, nil)
+			s.count("Neighbors each", most)
+			return nil
+		}},
+	"thirteen": {help: "Count nodes with exactly one neighbour",
+		run: func(ctx context.Context, s *Shell, args [][]string) error {
+			return s.scanCount(ctx, "Nodes with one neighbor", graph.CountSingleNeighbor)
+		}},
+	"fourteen": {args: []string{"old", "new"}, help: "Rename a node (needs --writable)",
+		run: func(ctx context.Context, s *Shell, args [][]string) error {
+			if !s.writable {
+				return fmt.Errorf("renaming changes the graph, start the shell with --writable")
+			}
+			oldName, newName := args[0][0], args[1][0]
+			if err := s.st.RenameNode(ctx, oldName, newName); err != nil {
+				return err
+			}
+			for _, nodes := range append([][]string{s.last}, mapValues(s.vars)...) {
+				for i, n := range nodes {
+					if n == oldName {
+						nodes[i] = newName
+					}
+				}
+			}
+			s.say(color.FgGreen, "✅ Renamed %s to %s", quote(oldName), quote(newName))
+			return nil
+		}},
+	"fifteen": {args: []string{"node"}, help: "Nodes sharing a parent or child over the same relation",
+		run: func(ctx context.Context, s *Shell, args [][]string) error {
+			return s.list(args[0], func(node string) ([]string, error) {
+				return graph.Similar(ctx, s.st, node)
+			})
+		}},
+	"sixteen": {args: []string{"source", "target"}, help: "Shortest path; the path becomes $_",
+		run: func(ctx context.Context, s *Shell, args [][]string) error {
+			path, length, visited, err := graph.ShortestPath(ctx, s.st, args[0][0], args[1][0])
+			if err != nil {
+				return err
+			}
+			if path == nil {
+				return fmt.Errorf("no path between %s and %s (%d nodes visited)", quote(args[0][0]), quote(args[1][0]), visited)
+			}
+			s.nodes(path, nil)
+			s.count("Length", length)
+			return nil
+		}},
+	"seventeen": {args: []string{"node", "distance"}, help: "Nodes a synonym path of that length reaches",
+		run: func(ctx context.Context, s *Shell, args [][]string) error {
+			return s.paths(ctx, args, graph.DistantSynonyms)
+		}},
+	"eighteen": {args: []string{"node", "distance"}, help: "Nodes an antonym path of that length reaches",
+		run: func(ctx context.Context, s *Shell, args [][]string) error {
+			return s.paths(ctx, args, graph.DistantAntonyms)
+		}},
+}
+
+// list runs fn for each node and prints the merged result.
+func (s *Shell) list(nodes []string, fn func(node string) ([]string, error)) error {
+	found, err := union(nodes, fn)
+	if err != nil {
+		return err
+	}
+	s.nodes(found, nil)
+	return nil
+}
+
+func (s *Shell) scanCount(ctx context.Context, what string, fn func(ctx context.Context, st store.GraphStore) (int, error)) error {
+	n, err := fn(ctx, s.st)
+	if err == nil {
+		s.count(what, n)
+	}
+	return err
+}
+
+func (s *Shell) paths(ctx context.Context, args [][]string, fn func(ctx context.Context, st store.GraphStore, node string, distance int) ([]graph.PathResult, error)) error {
+	distance, err := strconv.Atoi(args[1][0])
+	if err != nil || distance < 1 {
+		return fmt.Errorf("distance must be a positive number, got %q", args[1][0])
+	}
+	results, err := fn(ctx, s.st, args[0][0], distance)
+	if err != nil {
+		return err
+	}
+	via := map[string]string{}
+	nodes := make([]string, 0, len(results))
+	for _, r := range results {
+		via[r.Node] = strings.Join(r.Path, " -> ")
+		nodes = append(nodes, r.Node)
+	}
+	s.nodes(nodes, func(n string) string { return via[n] })
+	return nil
+}
+
+// union runs fn for each node and merges the results, keeping the first
+// position of each.
+func union(nodes []string, fn func(node string) ([]string, error)) ([]string, error) {
+	seen := map[string]bool{}
+	var merged []string
+	for _, node := range nodes {
+		found, err := fn(node)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", quote(node), err)
+		}
+		for _, f := range found {
+			if !seen[f] {
+				seen[f] = true
+				merged = append(merged, f)
+			}
+		}
+	}
+	return merged, nil
+}
+
+func sum(nodes []string, fn func(node string) (int, error)) (int, error) {
+	total := 0
+	for _, node := range nodes {
+		n, err := fn(node)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", quote(node), err)
+		}
+		total += n
+	}
+	return total, nil
+}
+
+func appendNew(list []string, item string) []string {
+	if slices.Contains(list, item) {
+		return list
+	}
+	return append(list, item)
+}
+
+func mapValues(m map[string][]string) [][]string {
+	values := make([][]string, 0, len(m))
+	for _, v := range m {
+		values = append(values, v)
+	}
+	return values
+}
+
+// Verbs lists the query verbs in query order.
+func Verbs() []string {
+	return []string{"one", "two", "three", "four", "five", "six", "seven", "eight", "nine",
+		"ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen"}
+}
@@ -0,0 +1,55 @@
+package shell
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// word is one argument of a shell line. Quoted words are taken literally,
+// so a node name starting with $ is written in quotes.
+type word struct {
+	text   string
+	quoted bool
+}
+
+// splitWords splits a line into words at spaces. A word holding spaces or
+// quotes is written as a Go string literal; a # at the start of a word
+// begins a comment.
+func splitWords(line string) ([]word, error) {
+	var words []word
+	s := line
+	for {
+		s = strings.TrimLeftFunc(s, unicode.IsSpace)
+		if s == "" || s[0] == '#' {
+			return words, nil
+		}
+		if s[0] == '"' || s[0] == '`' {
+			quoted, err := strconv.QuotedPrefix(s)
+			if err != nil {
+				return nil, fmt.Errorf("unterminated quote in %s", s)
+			}
+			text, _ := strconv.Unquote(quoted)
+			words = append(words, word{text: text, quoted: true})
+			s = s[len(quoted):]
+			continue
+		}
+		end := strings.IndexFunc(s, unicode.IsSpace)
+		if end < 0 {
+			end = len(s)
+		}
+		words = append(words, word{text: s[:end]})
+		s = s[end:]
+	}
+}
+
+// quote writes name so that splitWords reads it back as one word.
+func quote(name string) string {
+	if name == "" || strings.HasPrefix(name, "$") || strings.ContainsFunc(name, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '"' || r == '`' || r == '#' || !unicode.IsPrint(r)
+	}) {
+		return strconv.Quote(name)
+	}
+	return name
+}
@@ -0,0 +1,448 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/DavidZaya21/parser/model"
+	"github.com/gocql/gocql"
+)
+
+// Every statement binds its values. gocql prepares each one once per
+// connection and reuses it, and a node name is never spliced into CQL, so
+// names like /c/en/don't need no escaping.
+const (
+	selectSuccessorsStmt    = "SELECT to_node, relation, kgtk_id, relation_label, source, sentence FROM edges WHERE from_node = ?"
+	selectPredecessorsStmt  = "SELECT from_node, relation, kgtk_id, relation_label, source, sentence FROM edges_by_to WHERE to_node = ?"
+	selectNeighborsStmt     = "SELECT to_node FROM edges_bidirectional WHERE from_node = ?"
+	selectByRelationStmt    = "SELECT to_node, relation, kgtk_id, relation_label, source, sentence FROM edges_by_relation WHERE from_node = ?"
+	selectByRelationFrom    = selectByRelationStmt + " AND relation >= ?"
+	selectByRelationRange   = selectByRelationStmt + " AND relation >= ? AND relation < ?"
+	selectPredecessorsFrom  = selectPredecessorsStmt + " AND relation >= ?"
+	selectPredecessorsRange = selectPredecessorsStmt + " AND relation >= ? AND relation < ?"
+	selectLabelsStmt        = "SELECT label FROM node WHERE name = ?"
+	selectNodeNamesStmt     = "SELECT DISTINCT name FROM node"
+	selectAllEdgesStmt      = "SELECT from_node, to_node, relation, kgtk_id, relation_label, source, sentence FROM edges"
+	selectOutgoingRowsStmt  = "SELECT to_node, relation, edge_id, kgtk_id, relation_label, source, sentence FROM edges WHERE from_node = ?"
+	selectIncomingRowsStmt  = "SELECT from_node, relation, edge_id, kgtk_id, relation_label, source, sentence FROM edges_by_to WHERE to_node = ?"
+	selectBidiRowsStmt      = "SELECT to_node, edge_id FROM edges_bidirectional WHERE from_node = ?"
+	insertNodeStmt          = "INSERT INTO node (name, label, node_id) VALUES (?, ?, ?)"
+	insertEdgeStmt          = "INSERT INTO edges (from_node, to_node, relation, edge_id, kgtk_id, relation_label, source, sentence) VALUES (?, ?, ?, ?, ?, ?, ?, ?)"
+	insertByToStmt          = "INSERT INTO edges_by_to (to_node, relation, from_node, edge_id, kgtk_id, relation_label, source, sentence) VALUES (?, ?, ?, ?, ?, ?, ?, ?)"
+	insertByRelStmt         = "INSERT INTO edges_by_relation (from_node, relation, to_node, edge_id, kgtk_id, relation_label, source, sentence) VALUES (?, ?, ?, ?, ?, ?, ?, ?)"
+	insertBidiStmt          = "INSERT INTO edges_bidirectional (from_node, to_node, edge_id) VALUES (?, ?, ?)"
+	deleteEdgeStmt          = "DELETE FROM edges WHERE from_node = ? AND to_node = ? AND relation = ? AND edge_id = ?"
+	deleteByToStmt          = "DELETE FROM edges_by_to WHERE to_node = ? AND relation = ? AND from_node = ? AND edge_id = ?"
+	deleteByRelStmt         = "DELETE FROM edges_by_relation WHERE from_node = ? AND relation = ? AND to_node = ? AND edge_id = ?"
+	deleteBidiStmt          = "DELETE FROM edges_bidirectional WHERE from_node = ? AND to_node = ? AND edge_id = ?"
+	deleteOutgoingStmt      = "DELETE FROM edges WHERE from_node = ?"
+	deleteIncomingStmt      = "DELETE FROM edges_by_to WHERE to_node = ?"
+	deleteByRelationStmt    = "DELETE FROM edges_by_relation WHERE from_node = ?"
+	deleteNeighborsStmt     = "DELETE FROM edges_bidirectional WHERE from_node = ?"
+	deleteNodeStmt          = "DELETE FROM node WHERE name = ?"
+)
+
+// Cassandra serves a keyspace created by dbcli schema and filled by the
+// parser: edges by from_node, edges_by_to by to_node, edges_by_relation by
+// from_node and relation, edges_bidirectional for neighbour lookups and one
+// node row per label.
+type Cassandra struct {
+	session *gocql.Session
+}
+
+// NewCassandra wraps an open session. The session belongs to whoever opened
+// it: Close leaves it open so other stores in the process can keep using it.
+func NewCassandra(session *gocql.Session) *Cassandra {
+	return &Cassandra{session: session}
+}
+
+// Session returns the underlying session, for maintenance commands that work
+// on tables the queries do not use.
+func (c *Cassandra) Session() *gocql.Session {
+	return c.session
+}
+
+func (c *Cassandra) Successors(ctx context.Context, node string) ([]model.Edge, error) {
+	iter := c.session.Query(selectSuccessorsStmt, node).WithContext(ctx).Iter()
+	edge := model.Edge{FromNode: node}
+	var edges []model.Edge
+	for iter.Scan(&edge.ToNode, &edge.RelationType, &edge.ID, &edge.RelationLabel, &edge.Source, &edge.Sentence) {
+		edges = append(edges, edge)
+	}
+	return edges, iter.Close()
+}
+
+func (c *Cassandra) Predecessors(ctx context.Context, node string) ([]model.Edge, error) {
+	return c.PredecessorsByRelation(ctx, node, Relations{})
+}
+
+// SuccessorsByRelation reads one slice of edges_by_relation per included
+// range. There relation is the first clustering column, so each range is a
+// seek and the edges come back ordered by relation and to_node.
+func (c *Cassandra) SuccessorsByRelation(ctx context.Context, node string, rel Relations) ([]model.Edge, error) {
+	return collect(func(fn func(e model.Edge) error) error {
+		return c.eachEdge(ctx, node, rel, successorsByRelation, fn)
+	})
+}
+
+// PredecessorsByRelation is SuccessorsByRelation over edges_by_to, which
+// clusters on relation first as well.
+func (c *Cassandra) PredecessorsByRelation(ctx context.Context, node string, rel Relations) ([]model.Edge, error) {
+	return collect(func(fn func(e model.Edge) error) error {
+		return c.eachEdge(ctx, node, rel, predecessors, fn)
+	})
+}
+
+// EachSuccessor reads edges without a filter and edges_by_relation with
+// one, a page at a time. The next page is only fetched once fn has seen the
+// current one, and the fetch carries ctx, so a cancelled caller stops the
+// read at the next page.
+func (c *Cassandra) EachSuccessor(ctx context.Context, node string, rel Relations, fn func(e model.Edge) error) error {
+	if rel.All() {
+		return c.eachEdge(ctx, node, rel, successors, fn)
+	}
+	return c.eachEdge(ctx, node, rel, successorsByRelation, fn)
+}
+
+// EachPredecessor is EachSuccessor over edges_by_to.
+func (c *Cassandra) EachPredecessor(ctx context.Context, node string, rel Relations, fn func(e model.Edge) error) error {
+	return c.eachEdge(ctx, node, rel, predecessors, fn)
+}
+
+// edgeQueries read the edges of a node from one table: all of them, those
+// from a relation on, or those in a range of relations. The rows name the
+// far end of each edge, its to_node when outgoing is set.
+type edgeQueries struct {
+	all, from, between string
+	outgoing           bool
+}
+
+var (
+	successors           = edgeQueries{all: selectSuccessorsStmt, outgoing: true}
+	successorsByRelation = edgeQueries{selectByRelationStmt, selectByRelationFrom, selectByRelationRange, true}
+	predecessors         = edgeQueries{selectPredecessorsStmt, selectPredecessorsFrom, selectPredecessorsRange, false}
+)
+
+// eachEdge reads one slice of the relation clustering column per range rel
+// includes and calls fn with each edge that passes rel. Tables that do not
+// cluster on relation first only have qs.all, which the zero rel uses.
+func (c *Cassandra) eachEdge(ctx context.Context, node string, rel Relations, qs edgeQueries, fn func(e model.Edge) error) error {
+	for _, rg := range rel.ranges() {
+		var q *gocql.Query
+		switch {
+		case rg.From == "" && rg.To == "":
+			q = c.session.Query(qs.all, node)
+		case rg.To == "":
+			q = c.session.Query(qs.from, node, rg.From)
+		default:
+			q = c.session.Query(qs.between, node, rg.From, rg.To)
+		}
+		iter := q.WithContext(ctx).Iter()
+		edge := model.Edge{FromNode: node}
+		far := &edge.ToNode
+		if !qs.outgoing {
+			edge = model.Edge{ToNode: node}
+			far = &edge.FromNode
+		}
+		for iter.Scan(far, &edge.RelationType, &edge.ID, &edge.RelationLabel, &edge.Source, &edge.Sentence) {
+			if !rel.Match(edge.RelationType) {
+				continue
+			}
+			if err := fn(edge); err != nil {
+				iter.Close()
+				return err
+			}
+		}
+		if err := iter.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Cassandra) Neighbors(ctx context.Context, node string) ([]string, error) {
+	iter := c.session.Query(selectNeighborsStmt, node).WithContext(ctx).Iter()
+	var neighbor string
+	var neighbors []string
+	for iter.Scan(&neighbor) {
+		if neighbor != node {
+			neighbors = append(neighbors, neighbor)
+		}
+	}
+	return neighbors, iter.Close()
+}
+
+// SuccessorsPage reads one page of the edges partition. The cursor carries
+// the driver's paging state, so the next page starts where Cassandra
+// stopped rather than at an offset it would have to skip.
+func (c *Cassandra) SuccessorsPage(ctx context.Context, node string, p Page) ([]model.Edge, string, error) {
+	cur, err := decodeCursor(listSuccessors, node, p)
+	if err != nil {
+		return nil, "", err
+	}
+	iter := c.session.Query(selectSuccessorsStmt, node).WithContext(ctx).PageSize(p.size()).PageState(cur.State).Iter()
+	next := iter.PageState()
+	edge := model.Edge{FromNode: node}
+	var edges []model.Edge
+	for iter.Scan(&edge.ToNode, &edge.RelationType, &edge.ID, &edge.RelationLabel, &edge.Source, &edge.Sentence) {
+		edges = append(edges, edge)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, "", err
+	}
+	return edges, encodeCursor(listSuccessors, node, pageState(next), ""), nil
+}
+
+// PredecessorsPage is SuccessorsPage over edges_by_to.
+func (c *Cassandra) PredecessorsPage(ctx context.Context, node string, p Page) ([]model.Edge, string, error) {
+	cur, err := decodeCursor(listPredecessors, node, p)
+	if err != nil {
+		return nil, "", err
+	}
+	iter := c.session.Query(selectPredecessorsStmt, node).WithContext(ctx).PageSize(p.size()).PageState(cur.State).Iter()
+	next := iter.PageState()
+	edge := model.Edge{ToNode: node}
+	var edges []model.Edge
+	for iter.Scan(&edge.FromNode, &edge.RelationType, &edge.ID, &edge.RelationLabel, &edge.Source, &edge.Sentence) {
+		edges = append(edges, edge)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, "", err
+	}
+	return edges, encodeCursor(listPredecessors, node, pageState(next), ""), nil
+}
+
+// NeighborsPage reads one page of edges_bidirectional. A neighbour joined
+// by several edges has a row for each, next to each other, so the cursor
+// also keeps the last neighbour returned to skip its rows on the next page.
+// A page can hold fewer names than rows.
+func (c *Cassandra) NeighborsPage(ctx context.Context, node string, p Page) ([]string, string, error) {
+	cur, err := decodeCursor(listNeighbors, node, p)
+	if err != nil {
+		return nil, "", err
+	}
+	iter := c.session.Query(selectNeighborsStmt, node).WithContext(ctx).PageSize(p.size()).PageState(cur.State).Iter()
+	next := iter.PageState()
+	last := cur.After
+	var neighbor string
+	var neighbors []string
+	for iter.Scan(&neighbor) {
+		if neighbor != node && neighbor != last {
+			neighbors = append(neighbors, neighbor)
+			last = neighbor
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return nil, "", err
+	}
+	return neighbors, encodeCursor(listNeighbors, node, pageState(next), last), nil
+}
+
+// pageState is nil when the driver says there is no next page.
+func pageState(state []byte) []byte {
+	if len(state) == 0 {
+		return nil
+	}
+	return state
+}
+
+// Labels reads the node table, which keeps one row per (name, label), so a
+// node loaded from several rows or with '|'-separated aliases has several.
+func (c *Cassandra) Labels(ctx context.Context, node string) ([]string, error) {
+	iter := c.session.Query(selectLabelsStmt, node).WithContext(ctx).Iter()
+	var label string
+	var labels []string
+	for iter.Scan(&label) {
+		labels = append(labels, label)
+	}
+	return labels, iter.Close()
+}
+
+// Nodes scans node partitions rather than rows, since a node has one row per
+// label.
+func (c *Cassandra) Nodes(ctx context.Context, fn func(name string) error) error {
+	iter := c.session.Query(selectNodeNamesStmt).WithContext(ctx).Iter()
+	var name string
+	for iter.Scan(&name) {
+		if err := fn(name); err != nil {
+			iter.Close()
+			return err
+		}
+	}
+	return iter.Close()
+}
+
+// NodesWithPrefix has no range to read: the node table is partitioned by a
+// hash of the name. It scans the names in token order and keeps the ones
+// that match, stopping at limit. Callers bound the scan with ctx; when it
+// ends early the names found so far are returned with the context's error.
+func (c *Cassandra) NodesWithPrefix(ctx context.Context, prefix string, limit int) ([]string, error) {
+	var names []string
+	err := c.Nodes(ctx, func(name string) error {
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+			if limit > 0 && len(names) == limit {
+				return errLimit
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, errLimit) {
+		err = nil
+	}
+	sort.Strings(names)
+	return names, err
+}
+
+// errLimit stops a scan that has found enough.
+var errLimit = errors.New("limit reached")
+
+func (c *Cassandra) Edges(ctx context.Context, fn func(e model.Edge) error) error {
+	iter := c.session.Query(selectAllEdgesStmt).WithContext(ctx).Iter()
+	var e model.Edge
+	for iter.Scan(&e.FromNode, &e.ToNode, &e.RelationType, &e.ID, &e.RelationLabel, &e.Source, &e.Sentence) {
+		if err := fn(e); err != nil {
+			iter.Close()
+			return err
+		}
+	}
+	return iter.Close()
+}
+
+// RenameNode copies every label to the new name, rewrites each edge in edges,
+// edges_by_to and edges_by_relation under the id the loader would have given
+// it, moves the
+// edges_bidirectional rows and finally drops what is left of the old name.
+// Edges are moved one logged batch at a time, so a failed rename can be run
+// again to finish the job.
+func (c *Cassandra) RenameNode(ctx context.Context, oldName, newName string) error {
+	if oldName == newName {
+		return nil
+	}
+	labels, err := c.Labels(ctx, oldName)
+	if err != nil {
+		return fmt.Errorf("read labels of %s: %w", oldName, err)
+	}
+	if len(labels) == 0 {
+		return fmt.Errorf("%s: %w", oldName, ErrNodeNotFound)
+	}
+
+	for _, label := range labels {
+		err := c.session.Query(insertNodeStmt, newName, label, gocql.UUID(model.NodeID(newName))).
+			WithContext(ctx).Exec()
+		if err != nil {
+			return fmt.Errorf("insert node %s: %w", newName, err)
+		}
+	}
+
+	rename := func(node string) string {
+		if node == oldName {
+			return newName
+		}
+		return node
+	}
+
+	// Outgoing edges, self-loops included
+	iter := c.session.Query(selectOutgoingRowsStmt, oldName).WithContext(ctx).Iter()
+	var edge model.Edge
+	var edgeID gocql.UUID
+	for iter.Scan(&edge.ToNode, &edge.RelationType, &edgeID, &edge.ID, &edge.RelationLabel, &edge.Source, &edge.Sentence) {
+		oldTo := edge.ToNode
+		edge.FromNode, edge.ToNode = newName, rename(oldTo)
+		if err := c.moveEdge(ctx, edge, oldName, oldTo, edgeID); err != nil {
+			iter.Close()
+			return fmt.Errorf("move outgoing edge %s -> %s: %w", oldName, oldTo, err)
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return fmt.Errorf("read outgoing edges: %w", err)
+	}
+
+	// Incoming edges
+	iter = c.session.Query(selectIncomingRowsStmt, oldName).WithContext(ctx).Iter()
+	edge = model.Edge{}
+	for iter.Scan(&edge.FromNode, &edge.RelationType, &edgeID, &edge.ID, &edge.RelationLabel, &edge.Source, &edge.Sentence) {
+		if edge.FromNode == oldName {
+			continue // self-loop, already moved with the outgoing edges
+		}
+		edge.ToNode = newName
+		if err := c.moveEdge(ctx, edge, edge.FromNode, oldName, edgeID); err != nil {
+			iter.Close()
+			return fmt.Errorf("move incoming edge %s -> %s: %w", edge.FromNode, oldName, err)
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return fmt.Errorf("read incoming edges: %w", err)
+	}
+
+	if err := c.renameBidirectional(ctx, oldName, newName); err != nil {
+		return fmt.Errorf("rename bidirectional edges: %w", err)
+	}
+
+	// Drop whatever is left of the old partitions
+	b := c.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+	b.Query(deleteOutgoingStmt, oldName)
+	b.Query(deleteIncomingStmt, oldName)
+	b.Query(deleteByRelationStmt, oldName)
+	b.Query(deleteNodeStmt, oldName)
+	if err := c.session.ExecuteBatch(b); err != nil {
+		return fmt.Errorf("delete %s: %w", oldName, err)
+	}
+	return nil
+}
+
+// moveEdge writes e to edges, edges_by_to and edges_by_relation under the id
+// the loader would have given it and deletes the rows it replaces, in one
+// logged batch.
+func (c *Cassandra) moveEdge(ctx context.Context, e model.Edge, oldFrom, oldTo string, oldID gocql.UUID) error {
+	id := gocql.UUID(e.EdgeID())
+	b := c.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+	b.Query(deleteEdgeStmt, oldFrom, oldTo, e.RelationType, oldID)
+	b.Query(deleteByToStmt, oldTo, e.RelationType, oldFrom, oldID)
+	b.Query(deleteByRelStmt, oldFrom, e.RelationType, oldTo, oldID)
+	b.Query(insertEdgeStmt,
+		e.FromNode, e.ToNode, e.RelationType, id, e.ID, e.RelationLabel, e.Source, e.Sentence)
+	b.Query(insertByToStmt,
+		e.ToNode, e.RelationType, e.FromNode, id, e.ID, e.RelationLabel, e.Source, e.Sentence)
+	b.Query(insertByRelStmt,
+		e.FromNode, e.RelationType, e.ToNode, id, e.ID, e.RelationLabel, e.Source, e.Sentence)
+	return c.session.ExecuteBatch(b)
+}
+
+// renameBidirectional moves every edges_bidirectional row of oldName to
+// newName, in both directions.
+func (c *Cassandra) renameBidirectional(ctx context.Context, oldName, newName string) error {
+	iter := c.session.Query(selectBidiRowsStmt, oldName).WithContext(ctx).Iter()
+	var neighbor string
+	var id gocql.UUID
+	for iter.Scan(&neighbor, &id) {
+		if neighbor == oldName {
+			continue
+		}
+		pair := gocql.UUID(model.PairID(newName, neighbor))
+		b := c.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+		b.Query(deleteBidiStmt, neighbor, oldName, id)
+		b.Query(insertBidiStmt, newName, neighbor, pair)
+		b.Query(insertBidiStmt, neighbor, newName, pair)
+		if err := c.session.ExecuteBatch(b); err != nil {
+			iter.Close()
+			return err
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return err
+	}
+	return c.session.Query(deleteNeighborsStmt, oldName).WithContext(ctx).Exec()
+}
+
+// Stats counts with full scans; the keyspace keeps no counters.
+func (c *Cassandra) Stats(ctx context.Context) (Stats, error) {
+	return countAll(ctx, c)
+}
+
+func (c *Cassandra) Close() error {
+	return nil
+}
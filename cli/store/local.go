@@ -0,0 +1,193 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/DavidZaya21/parser/localdb"
+	"github.com/DavidZaya21/parser/model"
+)
+
+// Local serves a data directory written by the parser with --backend=local.
+// It holds the same tables as the keyspace, so the queries give the same
+// answers as on Cassandra.
+type Local struct {
+	db *localdb.DB
+}
+
+// OpenLocal opens the database in dir. Only a writable store can rename
+// nodes, and only one process can hold it writable at a time.
+func OpenLocal(dir string, writable bool) (*Local, error) {
+	db, err := localdb.Open(dir, !writable)
+	if err != nil {
+		return nil, err
+	}
+	return &Local{db: db}, nil
+}
+
+func (l *Local) Successors(ctx context.Context, node string) ([]model.Edge, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return l.db.Successors(node)
+}
+
+func (l *Local) Predecessors(ctx context.Context, node string) ([]model.Edge, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return l.db.Predecessors(node)
+}
+
+// SuccessorsByRelation reads the included ranges of edges_by_relation, like
+// the Cassandra store.
+func (l *Local) SuccessorsByRelation(ctx context.Context, node string, rel Relations) ([]model.Edge, error) {
+	return collect(func(fn func(e model.Edge) error) error {
+		return l.eachInRanges(ctx, node, rel, l.db.EachSuccessorByRelation, fn)
+	})
+}
+
+func (l *Local) PredecessorsByRelation(ctx context.Context, node string, rel Relations) ([]model.Edge, error) {
+	return collect(func(fn func(e model.Edge) error) error {
+		return l.EachPredecessor(ctx, node, rel, fn)
+	})
+}
+
+func (l *Local) EachSuccessor(ctx context.Context, node string, rel Relations, fn func(e model.Edge) error) error {
+	if rel.All() {
+		return l.db.EachSuccessor(node, func(e model.Edge) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			return fn(e)
+		})
+	}
+	return l.eachInRanges(ctx, node, rel, l.db.EachSuccessorByRelation, fn)
+}
+
+func (l *Local) EachPredecessor(ctx context.Context, node string, rel Relations, fn func(e model.Edge) error) error {
+	return l.eachInRanges(ctx, node, rel, l.db.EachPredecessorByRelation, fn)
+}
+
+// eachInRanges has each read the ranges rel includes and calls fn with the
+// edges that pass rel.
+func (l *Local) eachInRanges(ctx context.Context, node string, rel Relations, each func(node, from, to string, fn func(e model.Edge) error) error, fn func(e model.Edge) error) error {
+	for _, rg := range rel.ranges() {
+		err := each(node, rg.From, rg.To, func(e model.Edge) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if !rel.Match(e.RelationType) {
+				return nil
+			}
+			return fn(e)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *Local) SuccessorsPage(ctx context.Context, node string, p Page) ([]model.Edge, string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, "", err
+	}
+	cur, err := decodeCursor(listSuccessors, node, p)
+	if err != nil {
+		return nil, "", err
+	}
+	edges, next, err := l.db.SuccessorsPage(node, cur.State, p.size())
+	return edges, encodeCursor(listSuccessors, node, next, ""), localPageError(err)
+}
+
+func (l *Local) PredecessorsPage(ctx context.Context, node string, p Page) ([]model.Edge, string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, "", err
+	}
+	cur, err := decodeCursor(listPredecessors, node, p)
+	if err != nil {
+		return nil, "", err
+	}
+	edges, next, err := l.db.PredecessorsPage(node, cur.State, p.size())
+	return edges, encodeCursor(listPredecessors, node, next, ""), localPageError(err)
+}
+
+func (l *Local) NeighborsPage(ctx context.Context, node string, p Page) ([]string, string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, "", err
+	}
+	cur, err := decodeCursor(listNeighbors, node, p)
+	if err != nil {
+		return nil, "", err
+	}
+	neighbors, next, err := l.db.NeighborsPage(node, cur.State, p.size())
+	return neighbors, encodeCursor(listNeighbors, node, next, ""), localPageError(err)
+}
+
+func localPageError(err error) error {
+	if errors.Is(err, localdb.ErrBadPosition) {
+		return ErrBadCursor
+	}
+	return err
+}
+
+func (l *Local) Neighbors(ctx context.Context, node string) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return l.db.Neighbors(node)
+}
+
+func (l *Local) Labels(ctx context.Context, node string) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return l.db.Labels(node)
+}
+
+func (l *Local) Nodes(ctx context.Context, fn func(name string) error) error {
+	return l.db.Nodes(func(name string) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return fn(name)
+	})
+}
+
+func (l *Local) NodesWithPrefix(ctx context.Context, prefix string, limit int) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return l.db.NodesWithPrefix(prefix, limit)
+}
+
+func (l *Local) Edges(ctx context.Context, fn func(e model.Edge) error) error {
+	return l.db.Edges(func(e model.Edge) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return fn(e)
+	})
+}
+
+func (l *Local) RenameNode(ctx context.Context, oldName, newName string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	err := l.db.RenameNode(oldName, newName)
+	if errors.Is(err, localdb.ErrNodeNotFound) {
+		return fmt.Errorf("%s: %w", oldName, ErrNodeNotFound)
+	}
+	return err
+}
+
+func (l *Local) Stats(ctx context.Context) (Stats, error) {
+	nodes, edges, err := l.db.Counts()
+	return Stats{Nodes: nodes, Edges: edges}, err
+}
+
+func (l *Local) Close() error {
+	return l.db.Close()
+}
@@ -0,0 +1,372 @@
+package store
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/DavidZaya21/parser/loader"
+	"github.com/DavidZaya21/parser/model"
+	"github.com/google/uuid"
+)
+
+// Memory keeps a whole graph in maps. It is meant for small files: the query
+// logic runs against it offline and in tests, with the same results the
+// Cassandra backend gives for the same data.
+type Memory struct {
+	mu     sync.RWMutex
+	labels map[string][]string
+	out    map[string][]model.Edge
+	in     map[string][]model.Edge
+	ids    map[uuid.UUID]bool
+	edges  int64
+}
+
+// NewMemory returns an empty graph.
+func NewMemory() *Memory {
+	return &Memory{
+		labels: make(map[string][]string),
+		out:    make(map[string][]model.Edge),
+		in:     make(map[string][]model.Edge),
+		ids:    make(map[uuid.UUID]bool),
+	}
+}
+
+// LoadTSV reads a KGTK file the way the parser does, resolving columns from
+// its header, and returns the resulting graph.
+func LoadTSV(ctx context.Context, path string) (*Memory, error) {
+	cols, err := loader.ReadHeader(path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	m := NewMemory()
+	rows := make(chan loader.Row, 64)
+	done := make(chan error, 1)
+	go func() {
+		done <- loader.ReadRows(ctx, path, cols, loader.Position{}, rows)
+	}()
+	for row := range rows {
+		m.AddNode(row.From)
+		m.AddNode(row.To)
+		m.AddEdge(row.Edge)
+	}
+	if err := <-done; err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	return m, nil
+}
+
+// AddNode stores n, merging its labels with any already known for the name.
+func (m *Memory) AddNode(n model.Node) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.addLabels(n.Name, n.Labels)
+}
+
+// AddEdge stores e unless an edge with the same id is already there, which
+// is how the Cassandra tables dedupe a reloaded file.
+func (m *Memory) AddEdge(e model.Edge) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.addEdge(e)
+}
+
+// addLabels records the labels of name. Like the node table, which has one
+// row per label, a name without any label is not a node.
+func (m *Memory) addLabels(name string, labels []string) {
+	if name == "" || len(labels) == 0 {
+		return
+	}
+	node := model.Node{Name: name, Labels: m.labels[name]}
+	node.MergeLabels(labels)
+	m.labels[name] = node.Labels
+}
+
+func (m *Memory) addEdge(e model.Edge) {
+	id := e.EdgeID()
+	if m.ids[id] {
+		return
+	}
+	m.ids[id] = true
+	m.out[e.FromNode] = append(m.out[e.FromNode], e)
+	m.in[e.ToNode] = append(m.in[e.ToNode], e)
+	m.edges++
+}
+
+// Successors returns edges ordered by to_node and relation, the clustering
+// order of the edges table.
+func (m *Memory) Successors(ctx context.Context, node string) ([]model.Edge, error) {
+	m.mu.RLock()
+	edges := slices.Clone(m.out[node])
+	m.mu.RUnlock()
+	sortEdges(edges, func(e model.Edge) (string, string) { return e.ToNode, e.RelationType })
+	return edges, nil
+}
+
+// Predecessors returns edges ordered by relation and from_node, the
+// clustering order of edges_by_to.
+func (m *Memory) Predecessors(ctx context.Context, node string) ([]model.Edge, error) {
+	m.mu.RLock()
+	edges := slices.Clone(m.in[node])
+	m.mu.RUnlock()
+	sortEdges(edges, func(e model.Edge) (string, string) { return e.RelationType, e.FromNode })
+	return edges, nil
+}
+
+// SuccessorsByRelation returns edges ordered by relation and to_node, the
+// clustering order of edges_by_relation.
+func (m *Memory) SuccessorsByRelation(ctx context.Context, node string, rel Relations) ([]model.Edge, error) {
+	m.mu.RLock()
+	edges := rel.filter(slices.Clone(m.out[node]))
+	m.mu.RUnlock()
+	sortEdges(edges, func(e model.Edge) (string, string) { return e.RelationType, e.ToNode })
+	return edges, nil
+}
+
+func (m *Memory) PredecessorsByRelation(ctx context.Context, node string, rel Relations) ([]model.Edge, error) {
+	edges, err := m.Predecessors(ctx, node)
+	return rel.filter(edges), err
+}
+
+func (m *Memory) EachSuccessor(ctx context.Context, node string, rel Relations, fn func(e model.Edge) error) error {
+	var edges []model.Edge
+	if rel.All() {
+		edges, _ = m.Successors(ctx, node)
+	} else {
+		edges, _ = m.SuccessorsByRelation(ctx, node, rel)
+	}
+	return each(ctx, edges, fn)
+}
+
+func (m *Memory) EachPredecessor(ctx context.Context, node string, rel Relations, fn func(e model.Edge) error) error {
+	edges, _ := m.PredecessorsByRelation(ctx, node, rel)
+	return each(ctx, edges, fn)
+}
+
+// each calls fn for every edge until ctx is done or fn fails.
+func each(ctx context.Context, edges []model.Edge, fn func(e model.Edge) error) error {
+	for _, e := range edges {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sortEdges(edges []model.Edge, clustering func(model.Edge) (string, string)) {
+	slices.SortFunc(edges, func(a, b model.Edge) int {
+		a1, a2 := clustering(a)
+		b1, b2 := clustering(b)
+		if c := cmp.Compare(a1, b1); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(a2, b2); c != 0 {
+			return c
+		}
+		ida, idb := a.EdgeID(), b.EdgeID()
+		return bytes.Compare(ida[:], idb[:])
+	})
+}
+
+func (m *Memory) Neighbors(ctx context.Context, node string) ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	seen := make(map[string]bool)
+	var neighbors []string
+	add := func(n string) {
+		if n != node && !seen[n] {
+			seen[n] = true
+			neighbors = append(neighbors, n)
+		}
+	}
+	for _, e := range m.out[node] {
+		add(e.ToNode)
+	}
+	for _, e := range m.in[node] {
+		add(e.FromNode)
+	}
+	return neighbors, nil
+}
+
+// SuccessorsPage, PredecessorsPage and NeighborsPage cut pages out of the
+// full, sorted answer; the cursor holds the offset of the next page.
+func (m *Memory) SuccessorsPage(ctx context.Context, node string, p Page) ([]model.Edge, string, error) {
+	edges, _ := m.Successors(ctx, node)
+	return memoryPage(listSuccessors, node, edges, p)
+}
+
+func (m *Memory) PredecessorsPage(ctx context.Context, node string, p Page) ([]model.Edge, string, error) {
+	edges, _ := m.Predecessors(ctx, node)
+	return memoryPage(listPredecessors, node, edges, p)
+}
+
+func (m *Memory) NeighborsPage(ctx context.Context, node string, p Page) ([]string, string, error) {
+	neighbors, _ := m.Neighbors(ctx, node)
+	slices.Sort(neighbors)
+	return memoryPage(listNeighbors, node, neighbors, p)
+}
+
+func memoryPage[T any](list, node string, all []T, p Page) ([]T, string, error) {
+	cur, err := decodeCursor(list, node, p)
+	if err != nil {
+		return nil, "", err
+	}
+	offset := 0
+	if cur.State != nil {
+		if offset, err = strconv.Atoi(string(cur.State)); err != nil || offset < 0 {
+			return nil, "", ErrBadCursor
+		}
+	}
+	offset = min(offset, len(all))
+	end := min(offset+p.size(), len(all))
+	var next []byte
+	if end < len(all) {
+		next = []byte(strconv.Itoa(end))
+	}
+	return all[offset:end], encodeCursor(list, node, next, ""), nil
+}
+
+func (m *Memory) Labels(ctx context.Context, node string) ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return slices.Clone(m.labels[node]), nil
+}
+
+// Nodes visits names in sorted order, so output built from a scan is stable.
+func (m *Memory) Nodes(ctx context.Context, fn func(name string) error) error {
+	m.mu.RLock()
+	names := make([]string, 0, len(m.labels))
+	for name := range m.labels {
+		names = append(names, name)
+	}
+	m.mu.RUnlock()
+
+	sort.Strings(names)
+	for _, name := range names {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Memory) NodesWithPrefix(ctx context.Context, prefix string, limit int) ([]string, error) {
+	m.mu.RLock()
+	var names []string
+	for name := range m.labels {
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	m.mu.RUnlock()
+
+	sort.Strings(names)
+	if limit > 0 && len(names) > limit {
+		names = names[:limit]
+	}
+	return names, nil
+}
+
+// Edges visits edges grouped by from_node in sorted order, like a scan of
+// the edges table groups them by partition.
+func (m *Memory) Edges(ctx context.Context, fn func(e model.Edge) error) error {
+	m.mu.RLock()
+	froms := make([]string, 0, len(m.out))
+	for from := range m.out {
+		froms = append(froms, from)
+	}
+	m.mu.RUnlock()
+
+	sort.Strings(froms)
+	for _, from := range froms {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		edges, _ := m.Successors(ctx, from)
+		for _, e := range edges {
+			if err := fn(e); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (m *Memory) RenameNode(ctx context.Context, oldName, newName string) error {
+	if oldName == newName {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	labels, ok := m.labels[oldName]
+	if !ok {
+		return fmt.Errorf("%s: %w", oldName, ErrNodeNotFound)
+	}
+
+	rename := func(node string) string {
+		if node == oldName {
+			return newName
+		}
+		return node
+	}
+
+	// Every edge touching the old name is in its out or in list; a self-loop
+	// is in both and must only be moved once.
+	moved := slices.Clone(m.out[oldName])
+	for _, e := range m.in[oldName] {
+		if e.FromNode != oldName {
+			moved = append(moved, e)
+		}
+	}
+	for _, e := range moved {
+		m.removeEdge(e)
+		e.FromNode, e.ToNode = rename(e.FromNode), rename(e.ToNode)
+		m.addEdge(e)
+	}
+
+	delete(m.labels, oldName)
+	m.addLabels(newName, labels)
+	return nil
+}
+
+func (m *Memory) removeEdge(e model.Edge) {
+	id := e.EdgeID()
+	if !m.ids[id] {
+		return
+	}
+	delete(m.ids, id)
+	drop := func(edges []model.Edge) []model.Edge {
+		return slices.DeleteFunc(edges, func(x model.Edge) bool { return x.EdgeID() == id })
+	}
+	if m.out[e.FromNode] = drop(m.out[e.FromNode]); len(m.out[e.FromNode]) == 0 {
+		delete(m.out, e.FromNode)
+	}
+	if m.in[e.ToNode] = drop(m.in[e.ToNode]); len(m.in[e.ToNode]) == 0 {
+		delete(m.in, e.ToNode)
+	}
+	m.edges--
+}
+
+func (m *Memory) Stats(ctx context.Context) (Stats, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return Stats{Nodes: int64(len(m.labels)), Edges: m.edges}, nil
+}
+
+func (m *Memory) Close() error {
+	return nil
+}
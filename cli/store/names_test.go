@@ -0,0 +1,384 @@
+package store
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DavidZaya21/parser/config"
+	"github.com/DavidZaya21/parser/loader"
+	"github.com/DavidZaya21/parser/localdb"
+	"github.com/DavidZaya21/parser/model"
+	"github.com/DavidZayar/cli/schema"
+	"github.com/gocql/gocql"
+)
+
+// testdata/awkward.tsv chains the names of awkward_names.txt: name i is
+// labelled "awkward i", has an edge to the hub and one from the root, and a
+// synonym or antonym edge to the next name, the last one wrapping around to
+// the first.
+const (
+	awkwardTSV   = "../testdata/awkward.tsv"
+	awkwardNames = "../testdata/awkward_names.txt"
+	hub          = "/c/en/hub"
+	root         = "/c/en/root"
+)
+
+// cassandraEnv names the variable holding the contact points of a cluster
+// to run the Cassandra case against. The test creates a scratch keyspace
+// there and drops it afterwards.
+const cassandraEnv = "DBCLI_TEST_CASSANDRA"
+
+type awkwardName struct {
+	name, label, next, prev string
+}
+
+func readNames(t *testing.T) []awkwardName {
+	t.Helper()
+	f, err := os.Open(awkwardNames)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var lines []string
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		lines = append(lines, sc.Text())
+	}
+	if err := sc.Err(); err != nil {
+		t.Fatal(err)
+	}
+	names := make([]awkwardName, len(lines))
+	for i, line := range lines {
+		names[i] = awkwardName{
+			name:  line,
+			label: fmt.Sprintf("awkward %d", i),
+			next:  lines[(i+1)%len(lines)],
+			prev:  lines[(i+len(lines)-1)%len(lines)],
+		}
+	}
+	return names
+}
+
+// eachRow reads awkward.tsv the way the parser does and calls fn with every
+// row.
+func eachRow(t *testing.T, fn func(row loader.Row) error) {
+	t.Helper()
+	ctx := context.Background()
+	cols, err := loader.ReadHeader(awkwardTSV, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := make(chan loader.Row, 64)
+	done := make(chan error, 1)
+	go func() {
+		done <- loader.ReadRows(ctx, awkwardTSV, cols, loader.Position{}, rows)
+	}()
+	var failed error
+	for row := range rows {
+		if failed == nil {
+			failed = fn(row)
+		}
+	}
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if failed != nil {
+		t.Fatal(failed)
+	}
+}
+
+func loadMemory(t *testing.T) GraphStore {
+	t.Helper()
+	st, err := LoadTSV(context.Background(), awkwardTSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return st
+}
+
+// loadLocal writes awkward.tsv into a local database as the parser's
+// --backend=local does, and opens it writable.
+func loadLocal(t *testing.T) GraphStore {
+	t.Helper()
+	ctx := context.Background()
+	dir := t.TempDir()
+	db, err := localdb.Open(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	eachRow(t, func(row loader.Row) error {
+		if err := db.WriteNodes(ctx, []*model.Node{&row.From, &row.To}); err != nil {
+			return err
+		}
+		return db.WriteEdges(ctx, []*model.Edge{&row.Edge})
+	})
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	st, err := OpenLocal(dir, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { st.Close() })
+	return st
+}
+
+// loadCassandra creates a scratch keyspace on the cluster in
+// DBCLI_TEST_CASSANDRA with the schema migrations, and writes awkward.tsv
+// into every table as the parser does, every value bound.
+func loadCassandra(t *testing.T) GraphStore {
+	t.Helper()
+	hosts := os.Getenv(cassandraEnv)
+	if hosts == "" {
+		t.Skipf("set %s to Cassandra contact points to run against a scratch keyspace", cassandraEnv)
+	}
+	ctx := context.Background()
+	cfg := config.Defaults()
+	cfg.Hosts = strings.Split(hosts, ",")
+	cfg.Keyspace = fmt.Sprintf("dbcli_names_%d", time.Now().UnixNano())
+
+	cluster, err := cfg.Cluster()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cluster.Consistency = gocql.Quorum
+	admin, err := cluster.CreateSession()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(admin.Close)
+	opts := schema.Options{Keyspace: cfg.Keyspace, ReplicationClass: "SimpleStrategy", ReplicationFactor: 1, Compaction: "stcs"}
+	if _, err := schema.Init(ctx, admin, opts); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := admin.Query("DROP KEYSPACE IF EXISTS " + cfg.Keyspace).Exec(); err != nil {
+			t.Logf("drop keyspace %s: %v", cfg.Keyspace, err)
+		}
+	})
+
+	cluster.Keyspace = cfg.Keyspace
+	session, err := cluster.CreateSession()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(session.Close)
+
+	eachRow(t, func(row loader.Row) error {
+		b := session.NewBatch(gocql.UnloggedBatch).WithContext(ctx)
+		for _, n := range []model.Node{row.From, row.To} {
+			for _, label := range n.Labels {
+				b.Query(insertNodeStmt, n.Name, label, gocql.UUID(model.NodeID(n.Name)))
+			}
+		}
+		e := row.Edge
+		id := gocql.UUID(e.EdgeID())
+		b.Query(insertEdgeStmt, e.FromNode, e.ToNode, e.RelationType, id, e.ID, e.RelationLabel, e.Source, e.Sentence)
+		b.Query(insertByToStmt, e.ToNode, e.RelationType, e.FromNode, id, e.ID, e.RelationLabel, e.Source, e.Sentence)
+		b.Query(insertByRelStmt, e.FromNode, e.RelationType, e.ToNode, id, e.ID, e.RelationLabel, e.Source, e.Sentence)
+		if e.FromNode != e.ToNode {
+			pair := gocql.UUID(model.PairID(e.FromNode, e.ToNode))
+			b.Query(insertBidiStmt, e.FromNode, e.ToNode, pair)
+			b.Query(insertBidiStmt, e.ToNode, e.FromNode, pair)
+		}
+		return session.ExecuteBatch(b)
+	})
+	return NewCassandra(session)
+}
+
+func toNodes(edges []model.Edge) []string {
+	nodes := make([]string, 0, len(edges))
+	for _, e := range edges {
+		nodes = append(nodes, e.ToNode)
+	}
+	slices.Sort(nodes)
+	return nodes
+}
+
+func fromNodes(edges []model.Edge) []string {
+	nodes := make([]string, 0, len(edges))
+	for _, e := range edges {
+		nodes = append(nodes, e.FromNode)
+	}
+	slices.Sort(nodes)
+	return nodes
+}
+
+func sorted(names ...string) []string {
+	slices.Sort(names)
+	return names
+}
+
+// walk reads every page of size 1 from read, passing each cursor back.
+func walk[T any](t *testing.T, read func(p Page) ([]T, string, error)) []T {
+	t.Helper()
+	var all []T
+	cursor := ""
+	for pages := 0; ; pages++ {
+		if pages > 10 {
+			t.Fatalf("still paging after %d pages", pages)
+		}
+		items, next, err := read(Page{Size: 1, Cursor: cursor})
+		if err != nil {
+			t.Fatal(err)
+		}
+		all = append(all, items...)
+		if next == "" {
+			return all
+		}
+		cursor = next
+	}
+}
+
+// nameChecks are run against every awkward name. Each reads the name back
+// through one part of the store and compares it byte for byte.
+var nameChecks = []struct {
+	name  string
+	check func(t *testing.T, ctx context.Context, st GraphStore, n awkwardName)
+}{
+	{"labels", func(t *testing.T, ctx context.Context, st GraphStore, n awkwardName) {
+		labels, err := st.Labels(ctx, n.name)
+		if err != nil || !slices.Equal(labels, []string{n.label}) {
+			t.Errorf("labels = %q, err %v, want [%q]", labels, err, n.label)
+		}
+	}},
+	{"successors", func(t *testing.T, ctx context.Context, st GraphStore, n awkwardName) {
+		edges, err := st.Successors(ctx, n.name)
+		if got, want := toNodes(edges), sorted(hub, n.next); err != nil || !slices.Equal(got, want) {
+			t.Errorf("successors = %q, err %v, want %q", got, err, want)
+		}
+		for _, e := range edges {
+			if e.FromNode != n.name {
+				t.Errorf("successor edge starts at %q", e.FromNode)
+			}
+		}
+	}},
+	{"predecessors", func(t *testing.T, ctx context.Context, st GraphStore, n awkwardName) {
+		edges, err := st.Predecessors(ctx, n.name)
+		if got, want := fromNodes(edges), sorted(root, n.prev); err != nil || !slices.Equal(got, want) {
+			t.Errorf("predecessors = %q, err %v, want %q", got, err, want)
+		}
+		for _, e := range edges {
+			if e.ToNode != n.name {
+				t.Errorf("predecessor edge ends at %q", e.ToNode)
+			}
+		}
+	}},
+	{"by relation", func(t *testing.T, ctx context.Context, st GraphStore, n awkwardName) {
+		succ, err := st.SuccessorsByRelation(ctx, n.name, Relations{Include: []string{"/r/RelatedTo"}})
+		if got := toNodes(succ); err != nil || !slices.Equal(got, []string{hub}) {
+			t.Errorf("related successors = %q, err %v, want [%s]", got, err, hub)
+		}
+		pred, err := st.PredecessorsByRelation(ctx, n.name, Relations{Include: []string{"/r/IsA"}})
+		if got := fromNodes(pred); err != nil || !slices.Equal(got, []string{root}) {
+			t.Errorf("IsA predecessors = %q, err %v, want [%s]", got, err, root)
+		}
+		excluded, err := st.SuccessorsByRelation(ctx, n.name, Relations{Exclude: []string{"/r/RelatedTo"}})
+		if got := toNodes(excluded); err != nil || !slices.Equal(got, []string{n.next}) {
+			t.Errorf("successors but related = %q, err %v, want [%q]", got, err, n.next)
+		}
+	}},
+	{"neighbors", func(t *testing.T, ctx context.Context, st GraphStore, n awkwardName) {
+		neighbors, err := st.Neighbors(ctx, n.name)
+		slices.Sort(neighbors)
+		if want := sorted(hub, root, n.next, n.prev); err != nil || !slices.Equal(neighbors, want) {
+			t.Errorf("neighbors = %q, err %v, want %q", neighbors, err, want)
+		}
+	}},
+	{"pages", func(t *testing.T, ctx context.Context, st GraphStore, n awkwardName) {
+		succ := walk(t, func(p Page) ([]model.Edge, string, error) { return st.SuccessorsPage(ctx, n.name, p) })
+		if got, want := toNodes(succ), sorted(hub, n.next); !slices.Equal(got, want) {
+			t.Errorf("successor pages = %q, want %q", got, want)
+		}
+		pred := walk(t, func(p Page) ([]model.Edge, string, error) { return st.PredecessorsPage(ctx, n.name, p) })
+		if got, want := fromNodes(pred), sorted(root, n.prev); !slices.Equal(got, want) {
+			t.Errorf("predecessor pages = %q, want %q", got, want)
+		}
+		neighbors := walk(t, func(p Page) ([]string, string, error) { return st.NeighborsPage(ctx, n.name, p) })
+		slices.Sort(neighbors)
+		if want := sorted(hub, root, n.next, n.prev); !slices.Equal(neighbors, want) {
+			t.Errorf("neighbor pages = %q, want %q", neighbors, want)
+		}
+	}},
+	{"prefix", func(t *testing.T, ctx context.Context, st GraphStore, n awkwardName) {
+		names, err := st.NodesWithPrefix(ctx, n.name, 0)
+		if err != nil || !slices.Contains(names, n.name) {
+			t.Errorf("nodes with the name as prefix = %q, err %v", names, err)
+		}
+	}},
+	{"rename and back", func(t *testing.T, ctx context.Context, st GraphStore, n awkwardName) {
+		renamed := n.name + ".renamed"
+		if err := st.RenameNode(ctx, n.name, renamed); err != nil {
+			t.Fatal(err)
+		}
+		if labels, err := st.Labels(ctx, n.name); err != nil || len(labels) != 0 {
+			t.Errorf("old name keeps labels %q, err %v", labels, err)
+		}
+		edges, err := st.Successors(ctx, n.prev)
+		if err != nil || !slices.Contains(toNodes(edges), renamed) {
+			t.Errorf("successors of the previous name = %q, err %v, want %q among them", toNodes(edges), err, renamed)
+		}
+		if err := st.RenameNode(ctx, renamed, n.name); err != nil {
+			t.Fatal(err)
+		}
+		edges, err = st.Successors(ctx, n.name)
+		if got, want := toNodes(edges), sorted(hub, n.next); err != nil || !slices.Equal(got, want) {
+			t.Errorf("successors after renaming back = %q, err %v, want %q", got, err, want)
+		}
+	}},
+}
+
+// TestAwkwardNames reads every name in awkward_names.txt back through each
+// store: quotes, CQL fragments, format verbs, unicode in both normal forms,
+// pipes, spaces and a name of a few kilobytes. Every name must come back
+// exactly as it was loaded. The Cassandra case runs when
+// DBCLI_TEST_CASSANDRA is set.
+func TestAwkwardNames(t *testing.T) {
+	names := readNames(t)
+	backends := []struct {
+		name string
+		load func(t *testing.T) GraphStore
+	}{
+		{"memory", loadMemory},
+		{"local", loadLocal},
+		{"cassandra", loadCassandra},
+	}
+	for _, b := range backends {
+		t.Run(b.name, func(t *testing.T) {
+			ctx := context.Background()
+			st := b.load(t)
+			for i, n := range names {
+				for _, c := range nameChecks {
+					t.Run(fmt.Sprintf("%d/%s", i+1, c.name), func(t *testing.T) {
+						c.check(t, ctx, st, n)
+					})
+				}
+			}
+
+			stats, err := st.Stats(ctx)
+			if want := (Stats{Nodes: int64(len(names) + 2), Edges: int64(3 * len(names))}); err != nil || stats != want {
+				t.Errorf("stats = %+v, err %v, want %+v", stats, err, want)
+			}
+			var scanned []string
+			err = st.Nodes(ctx, func(name string) error {
+				scanned = append(scanned, name)
+				return nil
+			})
+			slices.Sort(scanned)
+			want := []string{hub, root}
+			for _, n := range names {
+				want = append(want, n.name)
+			}
+			if slices.Sort(want); err != nil || !slices.Equal(scanned, want) {
+				t.Errorf("node scan = %q, err %v, want %q", scanned, err, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package store
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"hash/fnv"
+)
+
+// ErrBadCursor is returned for a cursor that is not one a page of the same
+// listing of the same node returned.
+var ErrBadCursor = errors.New("bad cursor")
+
+// Page asks for one page of a listing: at most Size rows, starting where
+// the page that returned Cursor ended, or at the start for "".
+type Page struct {
+	Size   int
+	Cursor string
+}
+
+// DefaultPageSize is the page size of a Page without one.
+const DefaultPageSize = 100
+
+func (p Page) size() int {
+	if p.Size <= 0 {
+		return DefaultPageSize
+	}
+	return p.Size
+}
+
+// Listings a cursor can continue.
+const (
+	listSuccessors   = "s"
+	listPredecessors = "p"
+	listNeighbors    = "n"
+)
+
+// cursor is what an opaque cursor holds: the listing and node it belongs
+// to, so it cannot continue another one, and the backend's position, which
+// for Cassandra is the driver's paging state. After is the last row
+// returned, for backends that skip repeats across pages.
+type cursor struct {
+	List  string `json:"l"`
+	Node  uint64 `json:"n"`
+	State []byte `json:"s"`
+	After string `json:"a,omitempty"`
+}
+
+// encodeCursor returns the cursor of the next page, or "" when there is
+// none, which a nil state means.
+func encodeCursor(list, node string, state []byte, after string) string {
+	if state == nil {
+		return ""
+	}
+	data, _ := json.Marshal(cursor{List: list, Node: nodeHash(node), State: state, After: after})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor returns the position p.Cursor holds, nil for the first page.
+func decodeCursor(list, node string, p Page) (cursor, error) {
+	if p.Cursor == "" {
+		return cursor{}, nil
+	}
+	var c cursor
+	data, err := base64.RawURLEncoding.DecodeString(p.Cursor)
+	if err != nil || json.Unmarshal(data, &c) != nil || c.List != list || c.Node != nodeHash(node) || c.State == nil {
+		return cursor{}, ErrBadCursor
+	}
+	return c, nil
+}
+
+func nodeHash(node string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(node))
+	return h.Sum64()
+}
@@ -0,0 +1,143 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/DavidZaya21/parser/model"
+)
+
+// Relations filters edges by relation type. Include and Exclude hold
+// relation names such as /r/IsA, or prefixes ending in '*' such as /r/*.
+// An edge passes when Include is empty or one of its patterns matches, and
+// no Exclude pattern does. The zero value passes every edge.
+type Relations struct {
+	Include []string
+	Exclude []string
+}
+
+// ParseRelations reads --relation patterns: a pattern starting with '-'
+// excludes, any other includes.
+func ParseRelations(patterns []string) (Relations, error) {
+	var r Relations
+	for _, p := range patterns {
+		exclude := strings.HasPrefix(p, "-")
+		p = strings.TrimPrefix(p, "-")
+		if p == "" {
+			return Relations{}, fmt.Errorf("empty relation pattern")
+		}
+		if i := strings.IndexByte(p, '*'); i >= 0 && i != len(p)-1 {
+			return Relations{}, fmt.Errorf("relation pattern %q: '*' can only end a prefix", p)
+		}
+		if exclude {
+			r.Exclude = append(r.Exclude, p)
+		} else {
+			r.Include = append(r.Include, p)
+		}
+	}
+	return r, nil
+}
+
+// All reports whether r passes every edge.
+func (r Relations) All() bool {
+	return len(r.Include) == 0 && len(r.Exclude) == 0
+}
+
+// Match reports whether an edge of the given relation type passes r.
+func (r Relations) Match(relation string) bool {
+	for _, p := range r.Exclude {
+		if matchRelation(p, relation) {
+			return false
+		}
+	}
+	if len(r.Include) == 0 {
+		return true
+	}
+	for _, p := range r.Include {
+		if matchRelation(p, relation) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchRelation(pattern, relation string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(relation, prefix)
+	}
+	return pattern == relation
+}
+
+// filter keeps the edges that pass r.
+func (r Relations) filter(edges []model.Edge) []model.Edge {
+	if r.All() {
+		return edges
+	}
+	var out []model.Edge
+	for _, e := range edges {
+		if r.Match(e.RelationType) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// relationRange is the relation names from From up to, but not including,
+// To. An empty To has no upper bound.
+type relationRange struct {
+	From, To string
+}
+
+// ranges turns Include into sorted, non-overlapping ranges of the relation
+// clustering column, so a backend can read only those rows. A name is the
+// range up to the name followed by a zero byte, the next string there is;
+// a prefix ends where the names starting with it do. Exclude is left to
+// Match.
+func (r Relations) ranges() []relationRange {
+	if len(r.Include) == 0 {
+		return []relationRange{{}}
+	}
+	ranges := make([]relationRange, 0, len(r.Include))
+	for _, p := range r.Include {
+		if prefix, ok := strings.CutSuffix(p, "*"); ok {
+			ranges = append(ranges, relationRange{prefix, prefixEnd(prefix)})
+		} else {
+			ranges = append(ranges, relationRange{p, p + "\x00"})
+		}
+	}
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].From < ranges[j].From })
+
+	merged := ranges[:1]
+	for _, rg := range ranges[1:] {
+		last := &merged[len(merged)-1]
+		switch {
+		case last.To == "":
+		case rg.From <= last.To:
+			if rg.To == "" || rg.To > last.To {
+				last.To = rg.To
+			}
+		default:
+			merged = append(merged, rg)
+		}
+	}
+	return merged
+}
+
+// prefixEnd returns the first string after every string starting with
+// prefix, or "" when there is none. Cassandra only takes UTF-8 text, so an
+// end that is not leaves the range open and Match does the rest.
+func prefixEnd(prefix string) string {
+	b := []byte(prefix)
+	for i := len(b) - 1; i >= 0; i-- {
+		if b[i] < 0xff {
+			b[i]++
+			if end := string(b[:i+1]); utf8.ValidString(end) {
+				return end
+			}
+			return ""
+		}
+	}
+	return ""
+}
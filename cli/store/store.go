@@ -0,0 +1,99 @@
+// Package store hides where the graph lives from the dbcli queries. Every
+// command talks to a GraphStore; the Cassandra backend serves the loaded
+// keyspace and the in-memory backend serves a TSV file, which is enough to
+// run the queries offline and in tests.
+package store
+
+import (
+	"context"
+	"errors"
+
+	"github.com/DavidZaya21/parser/model"
+)
+
+// ErrNodeNotFound is returned by operations on a node that has no label row.
+var ErrNodeNotFound = errors.New("node not found")
+
+// GraphStore is the read and rename access the queries need. Edges returned
+// by Successors and Predecessors carry the full KGTK payload; node names are
+// compared exactly, as they are stored.
+type GraphStore interface {
+	// Successors returns the outgoing edges of node.
+	Successors(ctx context.Context, node string) ([]model.Edge, error)
+	// Predecessors returns the incoming edges of node.
+	Predecessors(ctx context.Context, node string) ([]model.Edge, error)
+	// EachSuccessor and EachPredecessor call fn for each edge of
+	// SuccessorsByRelation and PredecessorsByRelation as it is read, so a
+	// hub node's edges never have to be held at once; with a zero rel they
+	// come in the order of Successors and Predecessors. Returning an error
+	// from fn stops the read and is passed through.
+	EachSuccessor(ctx context.Context, node string, rel Relations, fn func(e model.Edge) error) error
+	EachPredecessor(ctx context.Context, node string, rel Relations, fn func(e model.Edge) error) error
+	// SuccessorsByRelation and PredecessorsByRelation return the edges of
+	// Successors and Predecessors whose relation type passes rel, reading
+	// only the relations it includes where the table allows.
+	SuccessorsByRelation(ctx context.Context, node string, rel Relations) ([]model.Edge, error)
+	PredecessorsByRelation(ctx context.Context, node string, rel Relations) ([]model.Edge, error)
+	// Neighbors returns the nodes joined to node by an edge in either
+	// direction, once each and without node itself.
+	Neighbors(ctx context.Context, node string) ([]string, error)
+	// SuccessorsPage, PredecessorsPage and NeighborsPage read one page of
+	// what Successors, Predecessors and Neighbors return, in the order of
+	// the table behind them, and the cursor of the next page: "" after the
+	// last. A hub node can be walked this way without reading it whole.
+	SuccessorsPage(ctx context.Context, node string, p Page) ([]model.Edge, string, error)
+	PredecessorsPage(ctx context.Context, node string, p Page) ([]model.Edge, string, error)
+	NeighborsPage(ctx context.Context, node string, p Page) ([]string, string, error)
+	// Labels returns every label stored for node.
+	Labels(ctx context.Context, node string) ([]string, error)
+	// Nodes calls fn once for every node name. Returning an error from fn
+	// stops the scan and is passed through.
+	Nodes(ctx context.Context, fn func(name string) error) error
+	// NodesWithPrefix returns up to limit node names starting with prefix,
+	// for completion. A limit of zero or less means no limit.
+	NodesWithPrefix(ctx context.Context, prefix string, limit int) ([]string, error)
+	// Edges calls fn once for every edge. Returning an error from fn stops
+	// the scan and is passed through.
+	Edges(ctx context.Context, fn func(e model.Edge) error) error
+	// RenameNode moves a node, its labels and every edge touching it to a
+	// new name.
+	RenameNode(ctx context.Context, oldName, newName string) error
+	// Stats counts the nodes and edges in the store.
+	Stats(ctx context.Context) (Stats, error)
+	// Close releases the connection or memory behind the store.
+	Close() error
+}
+
+// Stats is the size of a graph.
+type Stats struct {
+	Nodes int64 `json:"nodes"`
+	Edges int64 `json:"edges"`
+}
+
+// collect gathers the edges an Each method passes to fn.
+func collect(each func(fn func(e model.Edge) error) error) ([]model.Edge, error) {
+	var edges []model.Edge
+	err := each(func(e model.Edge) error {
+		edges = append(edges, e)
+		return nil
+	})
+	return edges, err
+}
+
+// countAll computes Stats with full scans, for backends that keep no
+// counters.
+func countAll(ctx context.Context, s GraphStore) (Stats, error) {
+	var stats Stats
+	err := s.Nodes(ctx, func(string) error {
+		stats.Nodes++
+		return nil
+	})
+	if err != nil {
+		return stats, err
+	}
+	err = s.Edges(ctx, func(model.Edge) error {
+		stats.Edges++
+		return nil
+	})
+	return stats, err
+}
@@ -11,33 +11,11 @@ done
 
 echo "Cassandra is healthy. Proceeding with keyspace and table creation..."
 
-docker exec -i cassandra-container cqlsh <<EOF
-CREATE KEYSPACE IF NOT EXISTS final_schema
-WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1};
-
-USE final_schema;
-
-CREATE TABLE edges (
-    from_node text,
-    to_node text,
-    relation text,
-    edge_id uuid,
-    PRIMARY KEY (from_node, to_node, relation, edge_id)
-);
-
-CREATE TABLE node (
-    name text,
-    label text,
-    node_id uuid,
-    PRIMARY KEY (name, label)
-);
-
-CREATE TABLE edges_bidirectional (
-    from_node text,
-    to_node text,
-    edge_id uuid,
-    PRIMARY KEY (from_node, to_node, edge_id)
-);
-EOF
-
-echo "Keyspace and tables created successfully!"
\ No newline at end of file
+# The keyspace and tables are created by versioned migrations compiled into
+# dbcli; the keyspace name and host come from .env.
+if [ ! -x ./bin/dbcli ]; then
+  make build
+fi
+./bin/dbcli schema init --replication-factor "${REPLICATION_FACTOR:-1}" --compaction "${COMPACTION:-stcs}"
+
+echo "Keyspace and tables created successfully!"
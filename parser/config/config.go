@@ -0,0 +1,164 @@
+// Package config holds the settings shared by the parser and dbcli: where
+// the cluster is, how to authenticate and route to it, and where a local
+// database lives. Settings are layered, each overriding the one before:
+// built-in defaults, a YAML file, the environment (and a .env file in the
+// working directory), then command-line flags.
+package config
+
+import (
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gocql/gocql"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrNotConfigured is returned when no contact point or keyspace is set.
+var ErrNotConfigured = errors.New("no Cassandra hosts or keyspace configured, set --hosts and --keyspace, HOST and KEYSPACE, or hosts and keyspace in the config file")
+
+// TLS locates the certificates for client-to-node encryption. Setting any
+// file turns TLS on.
+type TLS struct {
+	CAFile             string `yaml:"ca_file"`
+	CertFile           string `yaml:"cert_file"`
+	KeyFile            string `yaml:"key_file"`
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify"`
+}
+
+// Enabled reports whether any TLS setting is present.
+func (t TLS) Enabled() bool {
+	return t.CAFile != "" || t.CertFile != "" || t.KeyFile != "" || t.InsecureSkipVerify
+}
+
+// Config is the full set of settings. Field names are the keys of the YAML
+// file.
+type Config struct {
+	Hosts       []string `yaml:"hosts"`
+	Port        int      `yaml:"port"`
+	Keyspace    string   `yaml:"keyspace"`
+	Username    string   `yaml:"username"`
+	Password    string   `yaml:"password"`
+	TLS         TLS      `yaml:"tls"`
+	LocalDC     string   `yaml:"local_dc"`
+	Consistency string   `yaml:"consistency"`
+
+	Timeout        time.Duration `yaml:"timeout"`
+	ConnectTimeout time.Duration `yaml:"connect_timeout"`
+	NumConns       int           `yaml:"num_conns"`
+	Retries        int           `yaml:"retries"`
+	Compression    bool          `yaml:"compression"`
+
+	BatchSize int    `yaml:"batch_size"`
+	Backend   string `yaml:"backend"`
+	DataDir   string `yaml:"data_dir"`
+}
+
+// Defaults suit interactive queries against a single local node. There is
+// no default keyspace. The parser starts from the same values with longer
+// timeouts.
+func Defaults() Config {
+	return Config{
+		Hosts:          []string{"127.0.0.1"},
+		Port:           9042,
+		Consistency:    "ONE",
+		Timeout:        30 * time.Second,
+		ConnectTimeout: 10 * time.Second,
+		NumConns:       2,
+		Retries:        3,
+		Compression:    true,
+		BatchSize:      100,
+		Backend:        "cassandra",
+	}
+}
+
+// ReadFile overlays the keys present in a YAML file onto c. Keys the file
+// does not mention keep their current value.
+func (c *Config) ReadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// Validate checks the settings that do not depend on which binary runs.
+func (c *Config) Validate() error {
+	if _, err := gocql.ParseConsistencyWrapper(c.Consistency); err != nil {
+		return fmt.Errorf("invalid consistency: %w", err)
+	}
+	if c.Port < 1 || c.Port > 65535 {
+		return fmt.Errorf("invalid port %d", c.Port)
+	}
+	if c.BatchSize < 1 {
+		return fmt.Errorf("batch size must be at least 1, got %d", c.BatchSize)
+	}
+	if (c.TLS.CertFile == "") != (c.TLS.KeyFile == "") {
+		return errors.New("TLS needs both a certificate and a key file, or neither")
+	}
+	return nil
+}
+
+// Cluster returns a cluster configuration for the contact points. It does
+// not set the keyspace, so the same settings can open an admin session
+// before the keyspace exists.
+func (c *Config) Cluster() (*gocql.ClusterConfig, error) {
+	if len(c.Hosts) == 0 || c.Keyspace == "" {
+		return nil, ErrNotConfigured
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	cluster := gocql.NewCluster(c.Hosts...)
+	cluster.Port = c.Port
+	cluster.Consistency = gocql.ParseConsistency(c.Consistency)
+	cluster.Timeout = c.Timeout
+	cluster.ConnectTimeout = c.ConnectTimeout
+	if c.NumConns > 0 {
+		cluster.NumConns = c.NumConns
+	}
+	if c.Retries > 0 {
+		cluster.RetryPolicy = &gocql.ExponentialBackoffRetryPolicy{
+			Min:        100 * time.Millisecond,
+			Max:        10 * time.Second,
+			NumRetries: c.Retries,
+		}
+	}
+	if c.Compression {
+		cluster.Compressor = &gocql.SnappyCompressor{}
+	}
+	if c.Username != "" {
+		cluster.Authenticator = gocql.PasswordAuthenticator{Username: c.Username, Password: c.Password}
+	}
+	if c.TLS.Enabled() {
+		cluster.SslOpts = &gocql.SslOptions{
+			Config:                 &tls.Config{InsecureSkipVerify: c.TLS.InsecureSkipVerify},
+			CaPath:                 c.TLS.CAFile,
+			CertPath:               c.TLS.CertFile,
+			KeyPath:                c.TLS.KeyFile,
+			EnableHostVerification: !c.TLS.InsecureSkipVerify,
+		}
+	}
+	if c.LocalDC != "" {
+		// Token awareness sends each query to a replica, DC awareness keeps
+		// it in the local datacenter unless every local node is down.
+		cluster.PoolConfig.HostSelectionPolicy = gocql.TokenAwareHostPolicy(gocql.DCAwareRoundRobinPolicy(c.LocalDC))
+	}
+	return cluster, nil
+}
+
+// Target describes the contact points and keyspace for log lines. It never
+// includes the password.
+func (c *Config) Target() string {
+	return fmt.Sprintf("%s/%s", strings.Join(c.Hosts, ","), c.Keyspace)
+}
@@ -0,0 +1,241 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+// setting is one configurable value, reachable by flag and environment
+// variable under the names given here and by the YAML key of its field.
+type setting struct {
+	flag  string
+	env   []string // first match wins, later names are kept for older .env files
+	usage string
+	typ   string // shown in cobra's usage: string, strings, int, bool or duration
+	set   func(c *Config, v string) error
+	get   func(c *Config) string
+}
+
+var settings = []setting{
+	{flag: "hosts", env: []string{"CASSANDRA_HOSTS", "HOST"}, usage: "Comma-separated Cassandra contact points", typ: "strings",
+		set: func(c *Config, v string) error { c.Hosts = splitList(v); return nil },
+		get: func(c *Config) string { return strings.Join(c.Hosts, ",") }},
+	{flag: "port", env: []string{"CASSANDRA_PORT"}, usage: "Cassandra native protocol port", typ: "int",
+		set: func(c *Config, v string) error { return parseInt(v, &c.Port) },
+		get: func(c *Config) string { return strconv.Itoa(c.Port) }},
+	{flag: "keyspace", env: []string{"CASSANDRA_KEYSPACE", "KEYSPACE"}, usage: "Keyspace holding the graph", typ: "string",
+		set: func(c *Config, v string) error { c.Keyspace = v; return nil },
+		get: func(c *Config) string { return c.Keyspace }},
+	{flag: "username", env: []string{"CASSANDRA_USERNAME"}, usage: "Username for password authentication", typ: "string",
+		set: func(c *Config, v string) error { c.Username = v; return nil },
+		get: func(c *Config) string { return c.Username }},
+	{flag: "password", env: []string{"CASSANDRA_PASSWORD"}, usage: "Password for password authentication (prefer the environment)", typ: "string",
+		set: func(c *Config, v string) error { c.Password = v; return nil },
+		get: func(c *Config) string { return "" }},
+	{flag: "tls-ca", env: []string{"CASSANDRA_TLS_CA"}, usage: "CA certificate to verify the nodes with", typ: "string",
+		set: func(c *Config, v string) error { c.TLS.CAFile = v; return nil },
+		get: func(c *Config) string { return c.TLS.CAFile }},
+	{flag: "tls-cert", env: []string{"CASSANDRA_TLS_CERT"}, usage: "Client certificate for TLS", typ: "string",
+		set: func(c *Config, v string) error { c.TLS.CertFile = v; return nil },
+		get: func(c *Config) string { return c.TLS.CertFile }},
+	{flag: "tls-key", env: []string{"CASSANDRA_TLS_KEY"}, usage: "Client key for TLS", typ: "string",
+		set: func(c *Config, v string) error { c.TLS.KeyFile = v; return nil },
+		get: func(c *Config) string { return c.TLS.KeyFile }},
+	{flag: "tls-insecure-skip-verify", env: []string{"CASSANDRA_TLS_INSECURE_SKIP_VERIFY"}, usage: "Use TLS without verifying node certificates", typ: "bool",
+		set: func(c *Config, v string) error { return parseBool(v, &c.TLS.InsecureSkipVerify) },
+		get: func(c *Config) string { return strconv.FormatBool(c.TLS.InsecureSkipVerify) }},
+	{flag: "local-dc", env: []string{"CASSANDRA_LOCAL_DC"}, usage: "Route queries to this datacenter first", typ: "string",
+		set: func(c *Config, v string) error { c.LocalDC = v; return nil },
+		get: func(c *Config) string { return c.LocalDC }},
+	{flag: "consistency", env: []string{"CASSANDRA_CONSISTENCY"}, usage: "Consistency level of reads and writes", typ: "string",
+		set: func(c *Config, v string) error { c.Consistency = strings.ToUpper(v); return nil },
+		get: func(c *Config) string { return c.Consistency }},
+	{flag: "timeout", env: []string{"CASSANDRA_TIMEOUT"}, usage: "Per-query timeout", typ: "duration",
+		set: func(c *Config, v string) error { return parseDuration(v, &c.Timeout) },
+		get: func(c *Config) string { return c.Timeout.String() }},
+	{flag: "connect-timeout", env: []string{"CASSANDRA_CONNECT_TIMEOUT"}, usage: "Timeout to open a connection", typ: "duration",
+		set: func(c *Config, v string) error { return parseDuration(v, &c.ConnectTimeout) },
+		get: func(c *Config) string { return c.ConnectTimeout.String() }},
+	{flag: "num-conns", env: []string{"CASSANDRA_NUM_CONNS"}, usage: "Connections per host", typ: "int",
+		set: func(c *Config, v string) error { return parseInt(v, &c.NumConns) },
+		get: func(c *Config) string { return strconv.Itoa(c.NumConns) }},
+	{flag: "retries", env: []string{"CASSANDRA_RETRIES"}, usage: "Retries per query, with exponential backoff", typ: "int",
+		set: func(c *Config, v string) error { return parseInt(v, &c.Retries) },
+		get: func(c *Config) string { return strconv.Itoa(c.Retries) }},
+	{flag: "compression", env: []string{"CASSANDRA_COMPRESSION"}, usage: "Snappy-compress Cassandra traffic", typ: "bool",
+		set: func(c *Config, v string) error { return parseBool(v, &c.Compression) },
+		get: func(c *Config) string { return strconv.FormatBool(c.Compression) }},
+	{flag: "batch-size", env: []string{"BATCH_SIZE"}, usage: "Rows per write batch", typ: "int",
+		set: func(c *Config, v string) error { return parseInt(v, &c.BatchSize) },
+		get: func(c *Config) string { return strconv.Itoa(c.BatchSize) }},
+	{flag: "backend", env: []string{"GRAPH_BACKEND"}, usage: "Where the graph lives: cassandra or local, and memory for dbcli", typ: "string",
+		set: func(c *Config, v string) error { c.Backend = v; return nil },
+		get: func(c *Config) string { return c.Backend }},
+	{flag: "data-dir", env: []string{"GRAPH_DATA_DIR"}, usage: "Directory of the local database, for --backend=local", typ: "string",
+		set: func(c *Config, v string) error { c.DataDir = v; return nil },
+		get: func(c *Config) string { return c.DataDir }},
+}
+
+// Flags registers every setting as a command-line flag and remembers which
+// ones were given, so Load can apply them on top of the file and the
+// environment.
+type Flags struct {
+	base    Config
+	file    string
+	changed []func(c *Config) error
+}
+
+// NewFlags starts from base, which supplies the defaults shown in usage.
+func NewFlags(base Config) *Flags {
+	return &Flags{base: base}
+}
+
+// Register adds --config and one flag per setting to fs. dbcli hands the
+// set to cobra with AddGoFlagSet.
+func (f *Flags) Register(fs *flag.FlagSet) {
+	fs.StringVar(&f.file, "config", "", "YAML config file (default $GRAPH_CONFIG)")
+	for _, s := range settings {
+		v := &flagValue{flags: f, setting: s, value: s.get(&f.base)}
+		if s.typ == "bool" {
+			fs.Var(boolFlagValue{v}, s.flag, s.usage)
+		} else {
+			fs.Var(v, s.flag, s.usage)
+		}
+	}
+}
+
+// Load layers the defaults, the config file, the environment and the flags
+// that were given, and validates the result.
+func (f *Flags) Load() (Config, error) {
+	cfg := f.base
+	if err := godotenv.Load(".env"); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return cfg, fmt.Errorf("failed to load .env file: %w", err)
+	}
+
+	file := f.file
+	if file == "" {
+		file = os.Getenv("GRAPH_CONFIG")
+	}
+	if file != "" {
+		if err := cfg.ReadFile(file); err != nil {
+			return cfg, err
+		}
+	}
+
+	for _, s := range settings {
+		for _, name := range s.env {
+			if v, ok := os.LookupEnv(name); ok && v != "" {
+				if err := s.set(&cfg, v); err != nil {
+					return cfg, fmt.Errorf("invalid %s: %w", name, err)
+				}
+				break
+			}
+		}
+	}
+
+	for _, apply := range f.changed {
+		if err := apply(&cfg); err != nil {
+			return cfg, err
+		}
+	}
+	return cfg, cfg.Validate()
+}
+
+// flagValue records a flag as changed when it is set. The value is checked
+// against a scratch config right away, so bad input fails during parsing.
+type flagValue struct {
+	flags   *Flags
+	setting setting
+	value   string
+}
+
+func (v *flagValue) String() string {
+	if v == nil {
+		return ""
+	}
+	return v.value
+}
+
+func (v *flagValue) Set(s string) error {
+	var scratch Config
+	if err := v.setting.set(&scratch, s); err != nil {
+		return err
+	}
+	v.value = s
+	name := v.setting.flag
+	v.flags.changed = append(v.flags.changed, func(c *Config) error {
+		if err := v.setting.set(c, s); err != nil {
+			return fmt.Errorf("invalid --%s: %w", name, err)
+		}
+		return nil
+	})
+	return nil
+}
+
+// Type lets pflag use the value as it is instead of wrapping it.
+func (v *flagValue) Type() string {
+	return v.setting.typ
+}
+
+// boolFlagValue lets a bool setting be given without a value. Both flag
+// packages look for IsBoolFlag, so only bool settings may have it.
+type boolFlagValue struct {
+	*flagValue
+}
+
+func (boolFlagValue) IsBoolFlag() bool {
+	return true
+}
+
+// String reports false for the zero value, which is how the flag package
+// decides not to print (default false).
+func (b boolFlagValue) String() string {
+	if b.flagValue == nil {
+		return "false"
+	}
+	return b.value
+}
+
+func splitList(v string) []string {
+	var items []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func parseInt(v string, out *int) error {
+	n, err := strconv.Atoi(strings.TrimSpace(v))
+	if err != nil {
+		return err
+	}
+	*out = n
+	return nil
+}
+
+func parseBool(v string, out *bool) error {
+	b, err := strconv.ParseBool(strings.TrimSpace(v))
+	if err != nil {
+		return err
+	}
+	*out = b
+	return nil
+}
+
+func parseDuration(v string, out *time.Duration) error {
+	d, err := time.ParseDuration(strings.TrimSpace(v))
+	if err != nil {
+		return err
+	}
+	*out = d
+	return nil
+}
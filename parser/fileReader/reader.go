@@ -11,8 +11,6 @@ import (
 	"sync"
 )
 
-var filePath = "/Users/swanhtet1aungphyo/Downloads/cskg.tsv"
-
 func FileReader(filename string) *os.File {
 	file, err := os.Open(filename)
 	if err != nil {
@@ -34,12 +32,12 @@ func RawFileProcessing(file *os.File) []*model.Node {
 			continue
 		} else {
 			firstNodeApperance := &model.Node{
-				Name:  parts[1],
-				Label: parts[4],
+				Name:   parts[1],
+				Labels: model.SplitLabels(parts[4]),
 			}
 			secondNodApperance := &model.Node{
-				Name:  parts[3],
-				Label: parts[5],
+				Name:   parts[3],
+				Labels: model.SplitLabels(parts[5]),
 			}
 			nodes = append(nodes, firstNodeApperance)
 			nodes = append(nodes, secondNodApperance)
@@ -71,18 +69,22 @@ func RawDataToEdgeProcessing(file *os.File) []*model.Edge {
 	return edges
 }
 
+// RemoveNodeDuplication collapses repeated nodes into one per name, keeping
+// every distinct label seen for it rather than whichever came first.
 func RemoveNodeDuplication(nodes []*model.Node) []*model.Node {
 	var nodeMap sync.Map
 	var nonDuplicatedNode []*model.Node
 	for _, n := range nodes {
-		if strings.TrimSpace(n.Label) == "" || strings.TrimSpace(n.Name) == "" {
+		if len(n.Labels) == 0 || strings.TrimSpace(n.Name) == "" {
 			continue
 		}
-		key := n.Name
-		if _, ok := nodeMap.Load(key); !ok {
-			nonDuplicatedNode = append(nonDuplicatedNode, n)
-			nodeMap.Store(key, true)
+		if existing, ok := nodeMap.Load(n.Name); ok {
+			existing.(*model.Node).MergeLabels(n.Labels)
+			continue
 		}
+		merged := &model.Node{Name: n.Name, Labels: append([]string(nil), n.Labels...)}
+		nonDuplicatedNode = append(nonDuplicatedNode, merged)
+		nodeMap.Store(n.Name, merged)
 	}
 	return nonDuplicatedNode
 }
@@ -0,0 +1,113 @@
+package loader
+
+import (
+	"context"
+
+	"github.com/DavidZaya21/parser/model"
+)
+
+// Batch groups the node and edge writes produced by a run of consecutive rows.
+// Its Position is that of the last row in the batch.
+type Batch struct {
+	Position
+	Seq   int64
+	Nodes []*model.Node
+	Edges []*model.Edge
+}
+
+// Sink is where a load writes its batches. Writes must be idempotent: after
+// an interruption, every batch past the checkpoint is written again.
+type Sink interface {
+	WriteNodes(ctx context.Context, nodes []*model.Node) error
+	WriteEdges(ctx context.Context, edges []*model.Edge) error
+}
+
+// MakeBatches cuts the row stream into batches of at most size edges and
+// closes out once in is drained. Node labels already written by a recent
+// batch are skipped; the window is bounded by seenLimit so memory stays flat on inputs
+// with millions of distinct nodes. Anything that falls out of the window is
+// simply written again, which Cassandra treats as an upsert.
+func MakeBatches(ctx context.Context, in <-chan Row, size, seenLimit int, out chan<- *Batch) error {
+	defer close(out)
+
+	seen := newRecentSet(seenLimit)
+	var seq int64
+	batch := &Batch{}
+
+	flush := func() error {
+		if len(batch.Edges) == 0 && len(batch.Nodes) == 0 {
+			return nil
+		}
+		batch.Seq = seq
+		seq++
+		select {
+		case out <- batch:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		batch = &Batch{}
+		return nil
+	}
+
+	for row := range in {
+		for _, n := range []model.Node{row.From, row.To} {
+			if node := unseenLabels(seen, n); node != nil {
+				batch.Nodes = append(batch.Nodes, node)
+			}
+		}
+		edge := row.Edge
+		batch.Edges = append(batch.Edges, &edge)
+		batch.Position = row.Position
+
+		if len(batch.Edges) >= size {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return flush()
+}
+
+// unseenLabels returns n trimmed down to the labels not written recently, or
+// nil if there is nothing new to write.
+func unseenLabels(seen *recentSet, n model.Node) *model.Node {
+	if n.Name == "" {
+		return nil
+	}
+	node := &model.Node{Name: n.Name}
+	for _, label := range n.Labels {
+		key := n.Name + "\x00" + label
+		if seen.contains(key) {
+			continue
+		}
+		seen.add(key)
+		node.Labels = append(node.Labels, label)
+	}
+	if len(node.Labels) == 0 {
+		return nil
+	}
+	return node
+}
+
+// recentSet remembers up to limit keys and forgets all of them at once when
+// it fills up, which is cheaper than an LRU and good enough for dedup.
+type recentSet struct {
+	limit int
+	keys  map[string]struct{}
+}
+
+func newRecentSet(limit int) *recentSet {
+	return &recentSet{limit: limit, keys: make(map[string]struct{}, limit)}
+}
+
+func (s *recentSet) contains(key string) bool {
+	_, ok := s.keys[key]
+	return ok
+}
+
+func (s *recentSet) add(key string) {
+	if len(s.keys) >= s.limit {
+		s.keys = make(map[string]struct{}, s.limit)
+	}
+	s.keys[key] = struct{}{}
+}
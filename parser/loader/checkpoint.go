@@ -0,0 +1,163 @@
+package loader
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Position is a point in the input file: the number of lines consumed and the
+// byte offset just past the last of them.
+type Position struct {
+	Line   int64 `json:"line"`
+	Offset int64 `json:"offset"`
+}
+
+// Checkpoint records how far an import got. Everything before Position has
+// been written to Cassandra; a resumed import seeks straight to it.
+type Checkpoint struct {
+	File     string    `json:"file"`
+	Size     int64     `json:"size"`
+	Position Position  `json:"position"`
+	Complete bool      `json:"complete"`
+	Updated  time.Time `json:"updated"`
+}
+
+// LoadCheckpoint reads a checkpoint file. A missing file yields nil and no
+// error so a first run with --resume simply starts from the top.
+func LoadCheckpoint(path string) (*Checkpoint, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var cp Checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, err
+	}
+	return &cp, nil
+}
+
+// Save writes the checkpoint atomically so a crash mid-write never leaves a
+// truncated file behind.
+func (c *Checkpoint) Save(path string) error {
+	c.Updated = time.Now()
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Watermark follows batches that finish out of order and reports the furthest
+// position up to which every batch has been written. A batch that never
+// finishes holds the watermark back, so a resume will retry it. Once a batch
+// has failed the watermark cannot move past it, so later batches are turned
+// away rather than held: a resume rewrites them anyway.
+type Watermark struct {
+	mu      sync.Mutex
+	next    int64
+	pos     Position
+	pending map[int64]Position
+	failed  int64
+	err     error
+}
+
+func NewWatermark(start Position) *Watermark {
+	return &Watermark{pos: start, pending: make(map[int64]Position)}
+}
+
+// Done marks batch seq as written and returns the current watermark. Past a
+// failed batch it records nothing and returns that batch's failure.
+func (w *Watermark) Done(seq int64, end Position) (Position, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.check(seq); err != nil {
+		return w.pos, err
+	}
+	w.pending[seq] = end
+	for {
+		pos, ok := w.pending[w.next]
+		if !ok {
+			break
+		}
+		delete(w.pending, w.next)
+		w.pos = pos
+		w.next++
+	}
+	return w.pos, nil
+}
+
+// Fail marks batch seq as failed. The watermark stops short of it for good,
+// and batches held after it are dropped. Only the earliest failure is kept.
+func (w *Watermark) Fail(seq int64, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.err != nil && seq >= w.failed {
+		return
+	}
+	w.failed = seq
+	w.err = fmt.Errorf("batch %d: %w", seq, err)
+	for held := range w.pending {
+		if held > seq {
+			delete(w.pending, held)
+		}
+	}
+}
+
+// Check returns the failure that keeps the watermark from reaching batch seq,
+// if any, so a writer can skip batches that could never be checkpointed.
+func (w *Watermark) Check(seq int64) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.check(seq)
+}
+
+func (w *Watermark) check(seq int64) error {
+	if w.err != nil && seq > w.failed {
+		return w.err
+	}
+	return nil
+}
+
+// Err returns the earliest batch failure, or nil.
+func (w *Watermark) Err() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.err
+}
+
+// Position returns the current watermark.
+func (w *Watermark) Position() Position {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.pos
+}
+
+// Held reports how many finished batches are waiting on an earlier one.
+func (w *Watermark) Held() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.pending)
+}
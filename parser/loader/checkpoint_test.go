@@ -0,0 +1,62 @@
+package loader
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWatermarkOutOfOrder(t *testing.T) {
+	w := NewWatermark(Position{})
+	if pos, err := w.Done(1, Position{Line: 20}); err != nil || pos.Line != 0 {
+		t.Fatalf("after batch 1 alone: %+v, %v", pos, err)
+	}
+	if pos, err := w.Done(0, Position{Line: 10}); err != nil || pos.Line != 20 {
+		t.Fatalf("after batches 0 and 1: %+v, %v", pos, err)
+	}
+	if held := w.Held(); held != 0 {
+		t.Errorf("%d batches held", held)
+	}
+}
+
+// TestWatermarkFailedBatch fails one batch and then feeds many more: the
+// watermark must stop just before the failure, keep nothing after it and
+// hand the failure back.
+func TestWatermarkFailedBatch(t *testing.T) {
+	w := NewWatermark(Position{})
+	boom := errors.New("write timeout")
+
+	w.Done(0, Position{Line: 10})
+	w.Done(3, Position{Line: 40})
+	w.Fail(2, boom)
+	if held := w.Held(); held != 0 {
+		t.Errorf("batch 3 still held after batch 2 failed")
+	}
+	for seq := int64(4); seq < 100_000; seq++ {
+		if _, err := w.Done(seq, Position{Line: 10 * (seq + 1)}); !errors.Is(err, boom) {
+			t.Fatalf("batch %d: err %v, want %v", seq, err, boom)
+		}
+	}
+	if held := w.Held(); held != 0 {
+		t.Errorf("%d batches held behind the failed one", held)
+	}
+
+	// Batches before the failure still move the watermark up to it.
+	if pos, err := w.Done(1, Position{Line: 20}); err != nil || pos.Line != 20 {
+		t.Errorf("batch 1: %+v, %v, want line 20", pos, err)
+	}
+	if err := w.Check(1); err != nil {
+		t.Errorf("check batch 1: %v", err)
+	}
+	if err := w.Check(5); !errors.Is(err, boom) {
+		t.Errorf("check batch 5: %v, want %v", err, boom)
+	}
+
+	// Only the first failure is reported.
+	w.Fail(7, errors.New("later"))
+	if err := w.Err(); err == nil || err.Error() != "batch 2: write timeout" {
+		t.Errorf("err = %v, want the batch 2 failure", err)
+	}
+	if pos := w.Position(); pos.Line != 20 {
+		t.Errorf("watermark moved to line %d", pos.Line)
+	}
+}
@@ -0,0 +1,156 @@
+package loader
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// KGTK column names the loader understands.
+const (
+	ColID            = "id"
+	ColNode1         = "node1"
+	ColRelation      = "relation"
+	ColNode2         = "node2"
+	ColNode1Label    = "node1;label"
+	ColNode2Label    = "node2;label"
+	ColRelationLabel = "relation;label"
+	ColSource        = "source"
+	ColSentence      = "sentence"
+)
+
+var (
+	requiredColumns = []string{ColNode1, ColRelation, ColNode2}
+	knownColumns    = append(append([]string{}, requiredColumns...),
+		ColID, ColNode1Label, ColNode2Label, ColRelationLabel, ColSource, ColSentence)
+)
+
+// Columns holds the index of every known column in a line, or -1 when the
+// file does not have it.
+type Columns struct {
+	index   map[string]int
+	Missing []string
+}
+
+// ParseColumnMap parses a --columns value such as
+// "node1=subject,node2=object,relation=#2". Each entry maps a KGTK column to
+// either a header name or, with a leading '#', a zero-based column index.
+func ParseColumnMap(spec string) (map[string]string, error) {
+	mapping := make(map[string]string)
+	if strings.TrimSpace(spec) == "" {
+		return mapping, nil
+	}
+	for _, entry := range strings.Split(spec, ",") {
+		name, target, ok := strings.Cut(entry, "=")
+		name, target = strings.TrimSpace(name), strings.TrimSpace(target)
+		if !ok || name == "" || target == "" {
+			return nil, fmt.Errorf("invalid column mapping %q, expected column=header or column=#index", entry)
+		}
+		if !slices.Contains(knownColumns, name) {
+			return nil, fmt.Errorf("unknown column %q in mapping", name)
+		}
+		mapping[name] = target
+	}
+	return mapping, nil
+}
+
+// ReadHeader reads the header line of a KGTK file and resolves every known
+// column against it, applying mapping on top of the names in the header.
+func ReadHeader(path string, mapping map[string]string) (Columns, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Columns{}, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 256*1024)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return Columns{}, err
+		}
+		return Columns{}, fmt.Errorf("%s is empty, expected a KGTK header line", path)
+	}
+	return ParseHeader(scanner.Bytes(), mapping)
+}
+
+// ParseHeader resolves the known columns against a tab-separated header. It
+// fails when a required column cannot be found rather than guessing.
+func ParseHeader(header []byte, mapping map[string]string) (Columns, error) {
+	names := bytes.Split(header, []byte{'\t'})
+	byName := make(map[string]int, len(names))
+	for i, name := range names {
+		byName[string(bytes.TrimSpace(name))] = i
+	}
+
+	cols := Columns{index: make(map[string]int)}
+	for _, col := range knownColumns {
+		target, mapped := mapping[col]
+		if !mapped {
+			target = col
+		}
+
+		idx := -1
+		if strings.HasPrefix(target, "#") {
+			n, err := strconv.Atoi(target[1:])
+			if err != nil || n < 0 || n >= len(names) {
+				return Columns{}, fmt.Errorf("column %s mapped to %s, but the header has %d columns", col, target, len(names))
+			}
+			idx = n
+		} else if i, ok := byName[target]; ok {
+			idx = i
+		} else if mapped {
+			return Columns{}, fmt.Errorf("column %s mapped to %q, which is not in the header", col, target)
+		}
+
+		if idx < 0 {
+			if slices.Contains(requiredColumns, col) {
+				return Columns{}, fmt.Errorf("header has no %q column; use --columns to map it", col)
+			}
+			cols.Missing = append(cols.Missing, col)
+		}
+		cols.index[col] = idx
+	}
+	return cols, nil
+}
+
+// Index returns the position of col, or -1 if the file does not have it.
+func (c Columns) Index(col string) int {
+	if idx, ok := c.index[col]; ok {
+		return idx
+	}
+	return -1
+}
+
+// String describes the resolved mapping for the startup log.
+func (c Columns) String() string {
+	var parts []string
+	for _, col := range knownColumns {
+		if idx := c.Index(col); idx >= 0 {
+			parts = append(parts, fmt.Sprintf("%s=#%d", col, idx))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// fits reports whether a line split into parts has every required column.
+func (c Columns) fits(parts [][]byte) bool {
+	for _, col := range requiredColumns {
+		if c.Index(col) >= len(parts) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c Columns) field(parts [][]byte, col string) string {
+	idx := c.Index(col)
+	if idx < 0 || idx >= len(parts) {
+		return ""
+	}
+	return string(bytes.TrimSpace(parts[idx]))
+}
@@ -0,0 +1,120 @@
+package loader
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"os"
+
+	"github.com/DavidZaya21/parser/model"
+)
+
+// Row is one parsed KGTK line. Its Position points just past the line so a
+// batch built from it knows how far into the file it reaches.
+type Row struct {
+	Position
+	Edge model.Edge
+	From model.Node
+	To   model.Node
+}
+
+// ReadRows streams the rows of a KGTK TSV file into out and closes it when the
+// file is exhausted. Only the line being parsed is held in memory, so the
+// caller controls the memory footprint through the capacity of out. Fields are
+// picked out by cols, as resolved from the header by ReadHeader. A non-zero
+// from resumes reading at that position instead of just after the header.
+func ReadRows(ctx context.Context, path string, cols Columns, from Position, out chan<- Row) error {
+	defer close(out)
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	pos := Position{}
+	scanner := newLineScanner(f, &pos.Offset)
+
+	// Skip the header line
+	if !scanner.Scan() {
+		return scanner.Err()
+	}
+	pos.Line++
+
+	if from.Offset > pos.Offset {
+		if _, err := f.Seek(from.Offset, io.SeekStart); err != nil {
+			return err
+		}
+		pos = from
+		scanner = newLineScanner(f, &pos.Offset)
+	}
+
+	var malformed int64
+	defer func() {
+		if malformed > 0 {
+			log.Printf("⚠️  Skipped %d lines with fewer columns than the header", malformed)
+		}
+	}()
+
+	for scanner.Scan() {
+		pos.Line++
+		parts := bytes.Split(scanner.Bytes(), []byte{'\t'})
+		if !cols.fits(parts) {
+			if malformed == 0 {
+				log.Printf("⚠️  Line %d has only %d columns, skipping", pos.Line, len(parts))
+			}
+			malformed++
+			continue
+		}
+		row, ok := parseRow(parts, cols)
+		if !ok {
+			continue
+		}
+		row.Position = pos
+
+		select {
+		case out <- row:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return scanner.Err()
+}
+
+// newLineScanner returns a line scanner that adds the raw length of every line
+// it consumes, terminator included, to *offset.
+func newLineScanner(r io.Reader, offset *int64) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 256*1024)
+	scanner.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		advance, token, err := bufio.ScanLines(data, atEOF)
+		*offset += int64(advance)
+		return advance, token, err
+	})
+	return scanner
+}
+
+func parseRow(parts [][]byte, cols Columns) (Row, bool) {
+	from := cols.field(parts, ColNode1)
+	to := cols.field(parts, ColNode2)
+	rel := cols.field(parts, ColRelation)
+	if from == "" && to == "" && rel == "" {
+		return Row{}, false
+	}
+
+	return Row{
+		Edge: model.Edge{
+			ID:            cols.field(parts, ColID),
+			FromNode:      from,
+			ToNode:        to,
+			RelationType:  rel,
+			RelationLabel: cols.field(parts, ColRelationLabel),
+			Source:        cols.field(parts, ColSource),
+			Sentence:      cols.field(parts, ColSentence),
+		},
+		From: model.Node{Name: from, Labels: model.SplitLabels(cols.field(parts, ColNode1Label))},
+		To:   model.Node{Name: to, Labels: model.SplitLabels(cols.field(parts, ColNode2Label))},
+	}, true
+}
@@ -0,0 +1,98 @@
+package loader
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/gocql/gocql"
+)
+
+// Throttle bounds the number of batches in flight. The bound starts at max and
+// is halved whenever Cassandra reports that it is overloaded or timing out,
+// then grows back by one after a full window of successful writes.
+type Throttle struct {
+	mu        sync.Mutex
+	max       int
+	limit     int
+	inflight  int
+	successes int
+	wake      chan struct{}
+}
+
+func NewThrottle(max int) *Throttle {
+	if max < 1 {
+		max = 1
+	}
+	return &Throttle{max: max, limit: max, wake: make(chan struct{})}
+}
+
+// Acquire blocks until a slot is free or ctx is done.
+func (t *Throttle) Acquire(ctx context.Context) error {
+	for {
+		t.mu.Lock()
+		if t.inflight < t.limit {
+			t.inflight++
+			t.mu.Unlock()
+			return nil
+		}
+		wake := t.wake
+		t.mu.Unlock()
+
+		select {
+		case <-wake:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Release frees the slot taken by Acquire and adjusts the limit based on the
+// outcome of the write.
+func (t *Throttle) Release(err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.inflight--
+	switch {
+	case IsOverload(err):
+		t.limit = max(1, t.limit/2)
+		t.successes = 0
+	case err == nil && t.limit < t.max:
+		t.successes++
+		if t.successes >= t.limit {
+			t.limit++
+			t.successes = 0
+		}
+	}
+
+	close(t.wake)
+	t.wake = make(chan struct{})
+}
+
+// Limit reports the current number of batches allowed in flight.
+func (t *Throttle) Limit() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.limit
+}
+
+// IsOverload reports whether err means the cluster is shedding load, as
+// opposed to the batch itself being bad.
+func IsOverload(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, gocql.ErrTimeoutNoResponse) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var reqErr gocql.RequestError
+	if errors.As(err, &reqErr) {
+		switch reqErr.Code() {
+		case gocql.ErrCodeOverloaded, gocql.ErrCodeUnavailable,
+			gocql.ErrCodeWriteTimeout, gocql.ErrCodeReadTimeout:
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,320 @@
+// Package localdb stores the graph in a single bbolt file, for machines that
+// cannot run Cassandra. It keeps the same tables the keyspace has, as flat
+// buckets whose keys are the partition key followed by the clustering
+// columns, joined by a zero byte:
+//
+//	node                 name, label                   -> node_id
+//	edges                from, to, relation, edge_id   -> payload
+//	edges_by_to          to, relation, from, edge_id   -> payload
+//	edges_by_relation    from, relation, to, edge_id   -> payload
+//	edges_bidirectional  from, to                      -> pair id
+//
+// A prefix scan over "name\x00" then reads one partition, in clustering
+// order, the way a Cassandra query by partition key does.
+package localdb
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/DavidZaya21/parser/model"
+	"github.com/google/uuid"
+	bolt "go.etcd.io/bbolt"
+)
+
+// FileName is the name of the database file inside the data directory.
+const FileName = "graph.db"
+
+// version is bumped whenever the bucket layout changes. Version 1 had no
+// edges_by_relation; opening such a file writable fills it in.
+const (
+	version          = "2"
+	versionUnindexed = "1"
+)
+
+var (
+	bucketMeta          = []byte("meta")
+	bucketNode          = []byte("node")
+	bucketEdges         = []byte("edges")
+	bucketEdgesByTo     = []byte("edges_by_to")
+	bucketEdgesByRel    = []byte("edges_by_relation")
+	bucketBidirectional = []byte("edges_bidirectional")
+	keyVersion          = []byte("version")
+
+	// ErrNodeNotFound is returned when renaming a node that has no label.
+	ErrNodeNotFound = errors.New("node not found")
+	// ErrBadPosition is returned for a page position outside the node.
+	ErrBadPosition = errors.New("bad page position")
+)
+
+const sep = 0
+
+// DB is an open data directory.
+type DB struct {
+	bolt *bolt.DB
+}
+
+// Open opens the database in dir, creating both if needed. A read-only DB
+// can be shared with other readers; a writable one locks the file, and Open
+// gives up after a few seconds if another process holds it.
+func Open(dir string, readOnly bool) (*DB, error) {
+	path := filepath.Join(dir, FileName)
+	if readOnly {
+		if _, err := os.Stat(path); err != nil {
+			return nil, fmt.Errorf("no local database in %s: %w", dir, err)
+		}
+	} else if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	b, err := bolt.Open(path, 0o644, &bolt.Options{
+		Timeout:        5 * time.Second,
+		ReadOnly:       readOnly,
+		NoFreelistSync: true,
+		FreelistType:   bolt.FreelistMapType,
+	})
+	if errors.Is(err, bolt.ErrTimeout) {
+		return nil, fmt.Errorf("%s is locked by another process", path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	db := &DB{bolt: b}
+	if readOnly {
+		err = b.View(db.checkVersion)
+	} else if err = b.Update(db.init); err == nil {
+		err = db.upgrade()
+	}
+	if err != nil {
+		b.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+func (db *DB) init(tx *bolt.Tx) error {
+	for _, name := range [][]byte{bucketMeta, bucketNode, bucketEdges, bucketEdgesByTo, bucketEdgesByRel, bucketBidirectional} {
+		if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+			return err
+		}
+	}
+	meta := tx.Bucket(bucketMeta)
+	switch string(meta.Get(keyVersion)) {
+	case "":
+		return meta.Put(keyVersion, []byte(version))
+	case versionUnindexed:
+		return nil // upgrade fills edges_by_relation
+	}
+	return db.checkVersion(tx)
+}
+
+// upgradeChunk is how many edges one transaction of upgrade copies.
+const upgradeChunk = 10000
+
+// upgrade copies every edge of a version 1 file into edges_by_relation, a
+// chunk per transaction so a large file does not need one huge one, and
+// then marks the file current. The copies are idempotent, so an upgrade
+// that was interrupted starts over safely.
+func (db *DB) upgrade() error {
+	var current bool
+	err := db.bolt.View(func(tx *bolt.Tx) error {
+		current = string(tx.Bucket(bucketMeta).Get(keyVersion)) == version
+		return nil
+	})
+	if err != nil || current {
+		return err
+	}
+
+	var after []byte
+	for {
+		var edges []model.Edge
+		err := db.bolt.View(func(tx *bolt.Tx) (err error) {
+			after, err = scanPage(tx.Bucket(bucketEdges), nil, after, upgradeChunk, func(k, v []byte) error {
+				from, to, relation, _, ok := splitEdgeKey(k)
+				if !ok {
+					return nil
+				}
+				e, err := decodeEdge(from, to, relation, v)
+				edges = append(edges, e)
+				return err
+			})
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		err = db.bolt.Update(func(tx *bolt.Tx) error {
+			for i := range edges {
+				if err := putEdgeByRelation(tx, &edges[i]); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if after == nil {
+			break
+		}
+	}
+	return db.bolt.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketMeta).Put(keyVersion, []byte(version))
+	})
+}
+
+func (db *DB) checkVersion(tx *bolt.Tx) error {
+	meta := tx.Bucket(bucketMeta)
+	if meta == nil {
+		return fmt.Errorf("%s is not a graph database", db.bolt.Path())
+	}
+	if v := string(meta.Get(keyVersion)); v == versionUnindexed {
+		return fmt.Errorf("%s predates edges_by_relation; open it writable once, e.g. with dbcli index rebuild-by-relation, to add it", db.bolt.Path())
+	} else if v != version {
+		return fmt.Errorf("%s has layout version %q, this build reads %q; reload it", db.bolt.Path(), v, version)
+	}
+	return nil
+}
+
+// Close releases the file.
+func (db *DB) Close() error {
+	return db.bolt.Close()
+}
+
+// payload is the part of an edge that is not in its key.
+type payload struct {
+	ID            string `json:"id,omitempty"`
+	RelationLabel string `json:"relation_label,omitempty"`
+	Source        string `json:"source,omitempty"`
+	Sentence      string `json:"sentence,omitempty"`
+}
+
+func key(parts ...string) []byte {
+	var buf bytes.Buffer
+	for _, p := range parts {
+		buf.WriteString(p)
+		buf.WriteByte(sep)
+	}
+	return buf.Bytes()
+}
+
+// edgeKey is key(a, b, c) followed by the raw edge id.
+func edgeKey(a, b, c string, id uuid.UUID) []byte {
+	return append(key(a, b, c), id[:]...)
+}
+
+// splitEdgeKey undoes edgeKey. The id is raw bytes and may contain zeros, so
+// it is cut off by length before the names are split.
+func splitEdgeKey(k []byte) (a, b, c string, id uuid.UUID, ok bool) {
+	if len(k) < len(id)+1 {
+		return "", "", "", id, false
+	}
+	copy(id[:], k[len(k)-len(id):])
+	parts := bytes.Split(k[:len(k)-len(id)-1], []byte{sep})
+	if len(parts) != 3 {
+		return "", "", "", id, false
+	}
+	return string(parts[0]), string(parts[1]), string(parts[2]), id, true
+}
+
+func putEdge(tx *bolt.Tx, e *model.Edge) error {
+	value, err := json.Marshal(payload{ID: e.ID, RelationLabel: e.RelationLabel, Source: e.Source, Sentence: e.Sentence})
+	if err != nil {
+		return err
+	}
+	id := e.EdgeID()
+	if err := tx.Bucket(bucketEdges).Put(edgeKey(e.FromNode, e.ToNode, e.RelationType, id), value); err != nil {
+		return err
+	}
+	if err := tx.Bucket(bucketEdgesByTo).Put(edgeKey(e.ToNode, e.RelationType, e.FromNode, id), value); err != nil {
+		return err
+	}
+	if err := tx.Bucket(bucketEdgesByRel).Put(edgeKey(e.FromNode, e.RelationType, e.ToNode, id), value); err != nil {
+		return err
+	}
+	if e.FromNode == e.ToNode {
+		return nil
+	}
+	pair := model.PairID(e.FromNode, e.ToNode)
+	bidirectional := tx.Bucket(bucketBidirectional)
+	if err := bidirectional.Put(key(e.FromNode, e.ToNode), pair[:]); err != nil {
+		return err
+	}
+	return bidirectional.Put(key(e.ToNode, e.FromNode), pair[:])
+}
+
+// putEdgeByRelation writes e to edges_by_relation alone, for upgrade.
+func putEdgeByRelation(tx *bolt.Tx, e *model.Edge) error {
+	value, err := json.Marshal(payload{ID: e.ID, RelationLabel: e.RelationLabel, Source: e.Source, Sentence: e.Sentence})
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(bucketEdgesByRel).Put(edgeKey(e.FromNode, e.RelationType, e.ToNode, e.EdgeID()), value)
+}
+
+// scanPage calls fn for up to size keys starting with prefix that sort
+// after the key after, or from the first when after is nil. It returns the
+// last key it passed to fn if more follow, nil otherwise.
+func scanPage(b *bolt.Bucket, prefix, after []byte, size int, fn func(k, v []byte) error) ([]byte, error) {
+	if after != nil && !bytes.HasPrefix(after, prefix) {
+		return nil, ErrBadPosition
+	}
+	c := b.Cursor()
+	k, v := c.Seek(prefix)
+	if after != nil {
+		if k, v = c.Seek(after); bytes.Equal(k, after) {
+			k, v = c.Next()
+		}
+	}
+	var last []byte
+	for n := 0; k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+		if n == size {
+			return last, nil
+		}
+		if err := fn(k, v); err != nil {
+			return nil, err
+		}
+		// Keys are only valid in the transaction.
+		last = append(last[:0], k...)
+		n++
+	}
+	return nil, nil
+}
+
+// scanPrefix calls fn for every key in b that starts with prefix, in key
+// order.
+func scanPrefix(b *bolt.Bucket, prefix []byte, fn func(k, v []byte) error) error {
+	c := b.Cursor()
+	for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+		if err := fn(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scanRelations is scanPrefix over the keys of a partition clustered on
+// relation first, for the relations from from up to, but not including, to.
+// An empty to has no upper bound. Relations never hold the separator, so
+// keys sort the way the relations do and only the range is read.
+func scanRelations(b *bolt.Bucket, prefix []byte, from, to string, fn func(k, v []byte) error) error {
+	c := b.Cursor()
+	start := append(bytes.Clone(prefix), from...)
+	for k, v := c.Seek(start); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+		if to != "" {
+			if relation, _, _ := bytes.Cut(k[len(prefix):], []byte{sep}); string(relation) >= to {
+				return nil
+			}
+		}
+		if err := fn(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,262 @@
+package localdb
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/DavidZaya21/parser/model"
+	bolt "go.etcd.io/bbolt"
+)
+
+// Successors returns the outgoing edges of node, ordered by to_node and
+// relation like the edges partition.
+func (db *DB) Successors(node string) ([]model.Edge, error) {
+	var edges []model.Edge
+	err := db.EachSuccessor(node, func(e model.Edge) error {
+		edges = append(edges, e)
+		return nil
+	})
+	return edges, err
+}
+
+// EachSuccessor calls fn for each outgoing edge of node in the order of
+// Successors, inside one read transaction. Returning an error from fn stops
+// the scan and is passed through.
+func (db *DB) EachSuccessor(node string, fn func(e model.Edge) error) error {
+	return db.bolt.View(func(tx *bolt.Tx) error {
+		return scanPrefix(tx.Bucket(bucketEdges), key(node), func(k, v []byte) error {
+			from, to, relation, _, ok := splitEdgeKey(k)
+			if !ok {
+				return nil
+			}
+			e, err := decodeEdge(from, to, relation, v)
+			if err != nil {
+				return err
+			}
+			return fn(e)
+		})
+	})
+}
+
+// EachSuccessorByRelation calls fn for each outgoing edge of node whose
+// relation is from from up to, but not including, to, ordered by relation
+// and to_node like the edges_by_relation partition. An empty to has no
+// upper bound. Only the keys in the range are read.
+func (db *DB) EachSuccessorByRelation(node, from, to string, fn func(e model.Edge) error) error {
+	return db.bolt.View(func(tx *bolt.Tx) error {
+		return scanRelations(tx.Bucket(bucketEdgesByRel), key(node), from, to, func(k, v []byte) error {
+			fromNode, relation, toNode, _, ok := splitEdgeKey(k)
+			if !ok {
+				return nil
+			}
+			e, err := decodeEdge(fromNode, toNode, relation, v)
+			if err != nil {
+				return err
+			}
+			return fn(e)
+		})
+	})
+}
+
+// Predecessors returns the incoming edges of node, ordered by relation and
+// from_node like the edges_by_to partition.
+func (db *DB) Predecessors(node string) ([]model.Edge, error) {
+	var edges []model.Edge
+	err := db.EachPredecessor(node, func(e model.Edge) error {
+		edges = append(edges, e)
+		return nil
+	})
+	return edges, err
+}
+
+// EachPredecessor calls fn for each incoming edge of node in the order of
+// Predecessors, inside one read transaction. Returning an error from fn
+// stops the scan and is passed through.
+func (db *DB) EachPredecessor(node string, fn func(e model.Edge) error) error {
+	return db.EachPredecessorByRelation(node, "", "", fn)
+}
+
+// EachPredecessorByRelation is EachSuccessorByRelation over edges_by_to.
+func (db *DB) EachPredecessorByRelation(node, from, to string, fn func(e model.Edge) error) error {
+	return db.bolt.View(func(tx *bolt.Tx) error {
+		return scanRelations(tx.Bucket(bucketEdgesByTo), key(node), from, to, func(k, v []byte) error {
+			toNode, relation, fromNode, _, ok := splitEdgeKey(k)
+			if !ok {
+				return nil
+			}
+			e, err := decodeEdge(fromNode, toNode, relation, v)
+			if err != nil {
+				return err
+			}
+			return fn(e)
+		})
+	})
+}
+
+// SuccessorsPage returns up to size outgoing edges of node that come after
+// the key after, nil for the first page, and the key to pass for the next
+// page, nil when there is none.
+func (db *DB) SuccessorsPage(node string, after []byte, size int) ([]model.Edge, []byte, error) {
+	var edges []model.Edge
+	var next []byte
+	err := db.bolt.View(func(tx *bolt.Tx) (err error) {
+		next, err = scanPage(tx.Bucket(bucketEdges), key(node), after, size, func(k, v []byte) error {
+			from, to, relation, _, ok := splitEdgeKey(k)
+			if !ok {
+				return nil
+			}
+			e, err := decodeEdge(from, to, relation, v)
+			edges = append(edges, e)
+			return err
+		})
+		return err
+	})
+	return edges, next, err
+}
+
+// PredecessorsPage is SuccessorsPage for the incoming edges.
+func (db *DB) PredecessorsPage(node string, after []byte, size int) ([]model.Edge, []byte, error) {
+	var edges []model.Edge
+	var next []byte
+	err := db.bolt.View(func(tx *bolt.Tx) (err error) {
+		next, err = scanPage(tx.Bucket(bucketEdgesByTo), key(node), after, size, func(k, v []byte) error {
+			to, relation, from, _, ok := splitEdgeKey(k)
+			if !ok {
+				return nil
+			}
+			e, err := decodeEdge(from, to, relation, v)
+			edges = append(edges, e)
+			return err
+		})
+		return err
+	})
+	return edges, next, err
+}
+
+// NeighborsPage is SuccessorsPage for Neighbors.
+func (db *DB) NeighborsPage(node string, after []byte, size int) ([]string, []byte, error) {
+	var neighbors []string
+	var next []byte
+	prefix := key(node)
+	err := db.bolt.View(func(tx *bolt.Tx) (err error) {
+		next, err = scanPage(tx.Bucket(bucketBidirectional), prefix, after, size, func(k, _ []byte) error {
+			neighbors = append(neighbors, string(bytes.TrimSuffix(k[len(prefix):], []byte{sep})))
+			return nil
+		})
+		return err
+	})
+	return neighbors, next, err
+}
+
+// Neighbors returns the nodes edges_bidirectional pairs with node.
+func (db *DB) Neighbors(node string) ([]string, error) {
+	var neighbors []string
+	prefix := key(node)
+	err := db.bolt.View(func(tx *bolt.Tx) error {
+		return scanPrefix(tx.Bucket(bucketBidirectional), prefix, func(k, _ []byte) error {
+			neighbors = append(neighbors, string(bytes.TrimSuffix(k[len(prefix):], []byte{sep})))
+			return nil
+		})
+	})
+	return neighbors, err
+}
+
+// Labels returns the labels of node in sorted order.
+func (db *DB) Labels(node string) ([]string, error) {
+	var labels []string
+	prefix := key(node)
+	err := db.bolt.View(func(tx *bolt.Tx) error {
+		return scanPrefix(tx.Bucket(bucketNode), prefix, func(k, _ []byte) error {
+			labels = append(labels, string(bytes.TrimSuffix(k[len(prefix):], []byte{sep})))
+			return nil
+		})
+	})
+	return labels, err
+}
+
+// Nodes calls fn for every node name in sorted order. The scan runs in one
+// read transaction, so fn sees a consistent snapshot.
+func (db *DB) Nodes(fn func(name string) error) error {
+	return db.bolt.View(func(tx *bolt.Tx) error {
+		var last []byte
+		return tx.Bucket(bucketNode).ForEach(func(k, _ []byte) error {
+			name, _, _ := bytes.Cut(k, []byte{sep})
+			if last != nil && bytes.Equal(name, last) {
+				return nil
+			}
+			last = append(last[:0], name...)
+			return fn(string(name))
+		})
+	})
+}
+
+// NodesWithPrefix returns up to limit node names that start with prefix, in
+// sorted order. The names are a contiguous run of keys, so it reads only
+// those. A limit of zero or less returns them all.
+func (db *DB) NodesWithPrefix(prefix string, limit int) ([]string, error) {
+	var names []string
+	err := db.bolt.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(bucketNode).Cursor()
+		for k, _ := c.Seek([]byte(prefix)); k != nil && bytes.HasPrefix(k, []byte(prefix)); k, _ = c.Next() {
+			name, _, _ := bytes.Cut(k, []byte{sep})
+			if len(names) > 0 && names[len(names)-1] == string(name) {
+				continue
+			}
+			if limit > 0 && len(names) == limit {
+				break
+			}
+			names = append(names, string(name))
+		}
+		return nil
+	})
+	return names, err
+}
+
+// Edges calls fn for every edge, grouped by from_node in sorted order.
+func (db *DB) Edges(fn func(e model.Edge) error) error {
+	return db.bolt.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketEdges).ForEach(func(k, v []byte) error {
+			from, to, relation, _, ok := splitEdgeKey(k)
+			if !ok {
+				return nil
+			}
+			e, err := decodeEdge(from, to, relation, v)
+			if err != nil {
+				return err
+			}
+			return fn(e)
+		})
+	})
+}
+
+// Counts returns the number of nodes and edges.
+func (db *DB) Counts() (nodes, edges int64, err error) {
+	err = db.Nodes(func(string) error {
+		nodes++
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	err = db.bolt.View(func(tx *bolt.Tx) error {
+		edges = int64(tx.Bucket(bucketEdges).Stats().KeyN)
+		return nil
+	})
+	return nodes, edges, err
+}
+
+func decodeEdge(from, to, relation string, value []byte) (model.Edge, error) {
+	var p payload
+	if err := json.Unmarshal(value, &p); err != nil {
+		return model.Edge{}, err
+	}
+	return model.Edge{
+		ID:            p.ID,
+		FromNode:      from,
+		ToNode:        to,
+		RelationType:  relation,
+		RelationLabel: p.RelationLabel,
+		Source:        p.Source,
+		Sentence:      p.Sentence,
+	}, nil
+}
@@ -0,0 +1,108 @@
+package localdb
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/DavidZaya21/parser/model"
+	bolt "go.etcd.io/bbolt"
+)
+
+// RenameNode moves a node's labels and every edge touching it to newName in
+// one transaction. Edges are stored again under the id the loader would have
+// given them with the new name.
+func (db *DB) RenameNode(oldName, newName string) error {
+	if oldName == newName {
+		return nil
+	}
+	return db.bolt.Update(func(tx *bolt.Tx) error {
+		nodes := tx.Bucket(bucketNode)
+		prefix := key(oldName)
+		var labels []string
+		err := scanPrefix(nodes, prefix, func(k, _ []byte) error {
+			labels = append(labels, string(bytes.TrimSuffix(k[len(prefix):], []byte{sep})))
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if len(labels) == 0 {
+			return fmt.Errorf("%s: %w", oldName, ErrNodeNotFound)
+		}
+
+		// Collect before changing anything: bbolt cursors do not survive
+		// writes to the bucket they walk.
+		var moved []model.Edge
+		err = scanPrefix(tx.Bucket(bucketEdges), prefix, func(k, v []byte) error {
+			from, to, relation, _, _ := splitEdgeKey(k)
+			e, err := decodeEdge(from, to, relation, v)
+			moved = append(moved, e)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		err = scanPrefix(tx.Bucket(bucketEdgesByTo), prefix, func(k, v []byte) error {
+			to, relation, from, _, _ := splitEdgeKey(k)
+			if from == oldName {
+				return nil // self-loop, already collected with the outgoing edges
+			}
+			e, err := decodeEdge(from, to, relation, v)
+			moved = append(moved, e)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, e := range moved {
+			if err := deleteEdge(tx, e); err != nil {
+				return err
+			}
+		}
+		for _, e := range moved {
+			if e.FromNode == oldName {
+				e.FromNode = newName
+			}
+			if e.ToNode == oldName {
+				e.ToNode = newName
+			}
+			if err := putEdge(tx, &e); err != nil {
+				return err
+			}
+		}
+
+		id := model.NodeID(newName)
+		for _, label := range labels {
+			if err := nodes.Delete(key(oldName, label)); err != nil {
+				return err
+			}
+			if err := nodes.Put(key(newName, label), id[:]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// deleteEdge removes e from edges, edges_by_to, edges_by_relation and
+// edges_bidirectional. The
+// bidirectional rows go even if another relation still joins the pair;
+// putEdge writes them back for every edge that is re-added.
+func deleteEdge(tx *bolt.Tx, e model.Edge) error {
+	id := e.EdgeID()
+	if err := tx.Bucket(bucketEdges).Delete(edgeKey(e.FromNode, e.ToNode, e.RelationType, id)); err != nil {
+		return err
+	}
+	if err := tx.Bucket(bucketEdgesByTo).Delete(edgeKey(e.ToNode, e.RelationType, e.FromNode, id)); err != nil {
+		return err
+	}
+	if err := tx.Bucket(bucketEdgesByRel).Delete(edgeKey(e.FromNode, e.RelationType, e.ToNode, id)); err != nil {
+		return err
+	}
+	bidirectional := tx.Bucket(bucketBidirectional)
+	if err := bidirectional.Delete(key(e.FromNode, e.ToNode)); err != nil {
+		return err
+	}
+	return bidirectional.Delete(key(e.ToNode, e.FromNode))
+}
@@ -0,0 +1,45 @@
+package localdb
+
+import (
+	"context"
+
+	"github.com/DavidZaya21/parser/model"
+	bolt "go.etcd.io/bbolt"
+)
+
+// WriteNodes stores one node row per label. Concurrent calls are coalesced
+// into a single transaction, so many loader workers share one fsync.
+func (db *DB) WriteNodes(ctx context.Context, nodes []*model.Node) error {
+	if len(nodes) == 0 {
+		return ctx.Err()
+	}
+	return db.bolt.Batch(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketNode)
+		for _, n := range nodes {
+			id := model.NodeID(n.Name)
+			for _, label := range n.Labels {
+				if err := b.Put(key(n.Name, label), id[:]); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// WriteEdges stores every edge in edges, edges_by_to and edges_by_relation,
+// and both directions of every non-loop edge in edges_bidirectional. Keys
+// are derived from the edge, so writing a batch twice changes nothing.
+func (db *DB) WriteEdges(ctx context.Context, edges []*model.Edge) error {
+	if len(edges) == 0 {
+		return ctx.Err()
+	}
+	return db.bolt.Batch(func(tx *bolt.Tx) error {
+		for _, e := range edges {
+			if err := putEdge(tx, e); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
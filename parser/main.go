@@ -1,103 +1,254 @@
 package main
 
 import (
-	"bufio"
-	"bytes"
+	"context"
+	"flag"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"path/filepath"
 	"runtime"
 	"runtime/debug"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
 
+	"github.com/DavidZaya21/parser/config"
+	"github.com/DavidZaya21/parser/loader"
+	"github.com/DavidZaya21/parser/localdb"
 	"github.com/DavidZaya21/parser/model"
 	"github.com/fatih/color"
 	"github.com/gocql/gocql"
 )
 
 const (
-	batchSize     = 100
-	connectHost   = "127.0.0.1"
-	keyspaceName  = "final_schema"
+	batchBuffer   = 16
+	seenNodeLimit = 1 << 20
+	progressEvery = 1000
+	saveEvery     = 5 * time.Second
 	retryAttempts = 5
 	retryDelay    = 3 * time.Second
 )
 
 var (
-	session        *gocql.Session
-	insertEdgeStmt = "INSERT INTO edges (from_node, relation, to_node, edge_id) VALUES (?, ?, ?, ?)"
-	insertNodeStmt = "INSERT INTO node (name, label, node_id) VALUES (?, ?, uuid())"
+	workers     = flag.Int("workers", runtime.NumCPU(), "Number of concurrent batch writers")
+	maxInflight = flag.Int("max-inflight", 16, "Maximum batches in flight across the whole cluster")
+	checkpoint  = flag.String("checkpoint", "", "Checkpoint file (default <tsv-file-path>.checkpoint)")
+	resume      = flag.Bool("resume", false, "Continue from the last checkpoint instead of the top of the file")
+	columnMap   = flag.String("columns", "", "Map KGTK columns to header names or #indexes, e.g. node1=subject,node2=#3")
+	configFlags = config.NewFlags(loaderDefaults())
 )
 
-type Edge struct {
-	FromNode     string
-	ToNode       string
-	RelationType string
-}
+var (
+	cfg             config.Config
+	session         *gocql.Session
+	throttle        *loader.Throttle
+	insertEdgeStmt  = "INSERT INTO edges (from_node, relation, to_node, edge_id, kgtk_id, relation_label, source, sentence) VALUES (?, ?, ?, ?, ?, ?, ?, ?)"
+	insertNodeStmt  = "INSERT INTO node (name, label, node_id) VALUES (?, ?, ?)"
+	insertBidiStmt  = "INSERT INTO edges_bidirectional (from_node, to_node, edge_id) VALUES (?, ?, ?)"
+	insertByToStmt  = "INSERT INTO edges_by_to (to_node, relation, from_node, edge_id, kgtk_id, relation_label, source, sentence) VALUES (?, ?, ?, ?, ?, ?, ?, ?)"
+	insertByRelStmt = "INSERT INTO edges_by_relation (from_node, relation, to_node, edge_id, kgtk_id, relation_label, source, sentence) VALUES (?, ?, ?, ?, ?, ?, ?, ?)"
+)
 
 func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s [flags] <tsv-file-path>\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Example: %s --workers 8 /path/to/cskg.tsv\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	configFlags.Register(flag.CommandLine)
+	flag.Parse()
+
 	// Check command line arguments
-	if len(os.Args) != 2 {
-		fmt.Fprintf(os.Stderr, "Usage: %s <tsv-file-path>\n", os.Args[0])
-		fmt.Fprintf(os.Stderr, "Example: %s /path/to/cskg.tsv\n", os.Args[0])
+	if flag.NArg() != 1 || *workers < 1 || *maxInflight < 1 {
+		flag.Usage()
+		os.Exit(1)
+	}
+	var err error
+	cfg, err = configFlags.Load()
+	if err != nil {
+		color.Red("❌ %v", err)
+		os.Exit(1)
+	}
+	if cfg.Backend != "cassandra" && cfg.Backend != "local" {
+		color.Red("❌ Unknown backend %q, expected cassandra or local", cfg.Backend)
+		os.Exit(1)
+	}
+	if cfg.Backend == "local" && cfg.DataDir == "" {
+		color.Red("❌ --backend=local needs a --data-dir to write to")
 		os.Exit(1)
 	}
 
-	filePath := os.Args[1]
+	filePath := flag.Arg(0)
 
 	// Validate file exists
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+	info, err := os.Stat(filePath)
+	if os.IsNotExist(err) {
 		color.Red("❌ File does not exist: %s", filePath)
 		os.Exit(1)
 	}
+	if err != nil {
+		color.Red("❌ Cannot read file %s: %v", filePath, err)
+		os.Exit(1)
+	}
+	if *checkpoint == "" {
+		*checkpoint = filePath + ".checkpoint"
+	}
+	progress, err := openCheckpoint(filePath, info.Size())
+	if err != nil {
+		color.Red("❌ %v", err)
+		os.Exit(1)
+	}
+	if progress.Complete {
+		color.Green("✅ Checkpoint %s says this file is fully loaded, nothing to resume", *checkpoint)
+		return
+	}
+
+	mapping, err := loader.ParseColumnMap(*columnMap)
+	if err != nil {
+		color.Red("❌ %v", err)
+		os.Exit(1)
+	}
+	cols, err := loader.ReadHeader(filePath, mapping)
+	if err != nil {
+		color.Red("❌ %v", err)
+		os.Exit(1)
+	}
 
 	debug.SetGCPercent(500)
-	color.Green("🚀 Starting Cassandra loader...")
+	color.Green("🚀 Starting %s loader...", cfg.Backend)
 	color.Yellow("📁 Using file: %s", filePath)
+	color.Yellow("🧭 Columns: %s", cols)
+	if len(cols.Missing) > 0 {
+		color.Yellow("⚠️  Header has no %s column(s), those fields will be empty", strings.Join(cols.Missing, ", "))
+	}
 
-	if err := connectCassandra(); err != nil {
-		color.Red("❌ Cassandra connection failed: %v", err)
-		return
+	var out loader.Sink
+	if cfg.Backend == "local" {
+		db, err := localdb.Open(cfg.DataDir, false)
+		if err != nil {
+			color.Red("❌ Failed to open local database: %v", err)
+			return
+		}
+		defer db.Close()
+		out = db
+		color.Green("✅ Writing to %s", filepath.Join(cfg.DataDir, localdb.FileName))
+		color.Yellow("🧵 %d writers", *workers)
+	} else {
+		if err := connectCassandra(); err != nil {
+			color.Red("❌ Cassandra connection failed: %v", err)
+			return
+		}
+		defer session.Close()
+		out = cassandraSink{}
+
+		throttle = loader.NewThrottle(*maxInflight)
+		color.Yellow("🧵 %d writers, at most %d batches in flight across the cluster", *workers, *maxInflight)
 	}
-	defer session.Close()
 
 	var memBefore, memAfter runtime.MemStats
 	runtime.ReadMemStats(&memBefore)
 
-	color.Yellow("📂 Reading nodes from file...")
-	nodes, err := readNodes(filePath)
-	if err != nil {
-		color.Red("❌ Failed to read nodes: %v", err)
-		return
-	}
-	nodeBatches := createNodeBatches(nodes, batchSize)
-	color.Yellow("📦 Total nodes: %d (in %d batches)", len(nodes), len(nodeBatches))
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
 
-	color.Yellow("📂 Reading edges from file...")
-	edges, err := readEdges(filePath)
-	if err != nil {
-		color.Red("❌ Failed to read edges: %v", err)
-		return
+	watermark := loader.NewWatermark(progress.Position)
+	if progress.Position.Line > 0 {
+		color.Yellow("⏩ Resuming after line %d (byte %d)", progress.Position.Line, progress.Position.Offset)
 	}
-	edgeBatches := createEdgeBatches(edges, batchSize)
-	color.Yellow("🔗 Total edges: %d (in %d batches)", len(edges), len(edgeBatches))
+	stopSaving := saveCheckpointPeriodically(progress, watermark)
 
-	start := time.Now()
+	rows := make(chan loader.Row, 10*cfg.BatchSize)
+	batches := make(chan *loader.Batch, batchBuffer)
+	readErr := make(chan error, 1)
+	batchErr := make(chan error, 1)
 
-	color.Magenta("⚙️  Inserting nodes sequentially...")
-	for i, batch := range nodeBatches {
-		if err := retryInsertNodeBatch(batch); err != nil {
-			log.Printf("❌ Node batch %d failed after retries", i)
-		}
+	color.Yellow("📂 Streaming nodes and edges from file...")
+	// A failed batch pins the watermark, so reading on would only queue
+	// batches a resume has to write again; stopReading ends the pipeline.
+	readCtx, stopReading := context.WithCancel(ctx)
+	defer stopReading()
+	go func() { readErr <- loader.ReadRows(readCtx, filePath, cols, progress.Position, rows) }()
+	go func() { batchErr <- loader.MakeBatches(readCtx, rows, cfg.BatchSize, seenNodeLimit, batches) }()
+
+	start := time.Now()
+	var nodeCount, edgeCount, failed, written atomic.Int64
+	var wg sync.WaitGroup
+
+	color.Magenta("⚙️  Inserting batches concurrently...")
+	for i := 0; i < *workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range batches {
+				if ctx.Err() != nil || watermark.Check(batch.Seq) != nil {
+					continue
+				}
+				if err := out.WriteNodes(ctx, batch.Nodes); err != nil {
+					log.Printf("❌ Node batch %d failed after retries", batch.Seq)
+					failed.Add(1)
+					watermark.Fail(batch.Seq, err)
+					stopReading()
+					continue
+				}
+				if err := out.WriteEdges(ctx, batch.Edges); err != nil {
+					log.Printf("❌ Edge batch %d failed after retries", batch.Seq)
+					failed.Add(1)
+					watermark.Fail(batch.Seq, err)
+					stopReading()
+					continue
+				}
+				if _, err := watermark.Done(batch.Seq, batch.Position); err != nil {
+					continue
+				}
+				nodeCount.Add(int64(len(batch.Nodes)))
+				edgeCount.Add(int64(len(batch.Edges)))
+
+				if n := written.Add(1); n%progressEvery == 0 {
+					inFlight := ""
+					if throttle != nil {
+						inFlight = fmt.Sprintf(", %d in flight allowed", throttle.Limit())
+					}
+					color.Yellow("📦 %d batches written (%d nodes, %d edges%s)", n, nodeCount.Load(), edgeCount.Load(), inFlight)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	stopSaving()
+
+	readFailed, batchFailed := <-readErr, <-batchErr
+	progress.Position = watermark.Position()
+	progress.Complete = readFailed == nil && batchFailed == nil && failed.Load() == 0 && ctx.Err() == nil
+	if err := progress.Save(*checkpoint); err != nil {
+		color.Red("❌ Failed to save checkpoint: %v", err)
+	}
+	if !progress.Complete {
+		color.Yellow("💾 Checkpoint saved at line %d, rerun with --resume to continue", progress.Position.Line)
 	}
 
-	color.Magenta("⚙️  Inserting edges sequentially...")
-	for i, batch := range edgeBatches {
-		if err := retryInsertEdgeBatch(batch); err != nil {
-			log.Printf("❌ Edge batch %d failed after retries", i)
-		}
+	if ctx.Err() != nil {
+		color.Red("❌ Interrupted")
+		return
+	}
+	if err := watermark.Err(); err != nil {
+		color.Red("❌ Stopped at the first failed batch, %v", err)
+		return
+	}
+	if readFailed != nil {
+		color.Red("❌ Failed to read file: %v", readFailed)
+		return
+	}
+	if batchFailed != nil {
+		color.Red("❌ Failed to build batches: %v", batchFailed)
+		return
 	}
 
+	color.Yellow("📦 Total node writes: %d", nodeCount.Load())
+	color.Yellow("🔗 Total edges: %d", edgeCount.Load())
 	color.Green("✅ All inserts completed in %s", time.Since(start))
 
 	runtime.ReadMemStats(&memAfter)
@@ -105,166 +256,205 @@ func main() {
 	color.Green("🎉 Done!")
 }
 
-func connectCassandra() error {
-	cluster := gocql.NewCluster(connectHost)
-	cluster.Keyspace = keyspaceName
-	cluster.Consistency = gocql.One
-	cluster.Timeout = 2 * time.Minute
-	cluster.ConnectTimeout = 5 * time.Minute
-	cluster.RetryPolicy = &gocql.ExponentialBackoffRetryPolicy{
-		Min:        time.Second,
-		Max:        10 * time.Second,
-		NumRetries: 5,
-	}
-	cluster.Compressor = &gocql.SnappyCompressor{}
-
-	var err error
-	session, err = cluster.CreateSession()
-	if err == nil {
-		color.Green("✅ Connected to Cassandra at %s/%s", connectHost, keyspaceName)
-	}
-	return err
-}
-
-func readNodes(path string) ([]*model.Node, error) {
-	f, err := os.Open(path)
+// openCheckpoint returns the checkpoint to start from. Without --resume that
+// is a fresh one; with it, the saved checkpoint must describe the same file.
+func openCheckpoint(filePath string, size int64) (*loader.Checkpoint, error) {
+	abs, err := filepath.Abs(filePath)
 	if err != nil {
 		return nil, err
 	}
-	defer f.Close()
-
-	nodeMap := make(map[string]*model.Node)
-	scanner := bufio.NewScanner(f)
-	scanner.Buffer(make([]byte, 0, 64*1024), 256*1024)
-
-	if !scanner.Scan() {
-		return nil, scanner.Err()
+	fresh := &loader.Checkpoint{File: abs, Size: size}
+	if !*resume {
+		return fresh, nil
 	}
 
-	for scanner.Scan() {
-		parts := bytes.Split(scanner.Bytes(), []byte{'\t'})
-		if len(parts) < 6 {
-			continue
-		}
-		name1 := string(bytes.TrimSpace(parts[1]))
-		label1 := string(bytes.TrimSpace(parts[4]))
-		name2 := string(bytes.TrimSpace(parts[3]))
-		label2 := string(bytes.TrimSpace(parts[5]))
-
-		if name1 != "" && label1 != "" {
-			nodeMap[name1] = &model.Node{Name: name1, Label: label1}
-		}
-		if name2 != "" && label2 != "" {
-			nodeMap[name2] = &model.Node{Name: name2, Label: label2}
-		}
+	saved, err := loader.LoadCheckpoint(*checkpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint %s: %w", *checkpoint, err)
 	}
-
-	nodes := make([]*model.Node, 0, len(nodeMap))
-	for _, n := range nodeMap {
-		nodes = append(nodes, n)
+	if saved == nil {
+		color.Yellow("⚠️  No checkpoint at %s, starting from the top", *checkpoint)
+		return fresh, nil
+	}
+	if saved.File != abs || saved.Size != size {
+		return nil, fmt.Errorf("checkpoint %s was written for %s (%d bytes), not this file", *checkpoint, saved.File, saved.Size)
 	}
-	return nodes, scanner.Err()
+	return saved, nil
 }
 
-func readEdges(path string) ([]*Edge, error) {
-	f, err := os.Open(path)
-	if err != nil {
-		return nil, err
+// saveCheckpointPeriodically persists the watermark every few seconds until
+// the returned function is called.
+func saveCheckpointPeriodically(progress *loader.Checkpoint, watermark *loader.Watermark) func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(saveEvery)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				snapshot := *progress
+				snapshot.Position = watermark.Position()
+				if err := snapshot.Save(*checkpoint); err != nil {
+					log.Printf("⚠️  Failed to save checkpoint: %v", err)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		<-stopped
 	}
-	defer f.Close()
+}
 
-	var edges []*Edge
-	scanner := bufio.NewScanner(f)
-	scanner.Buffer(make([]byte, 0, 64*1024), 256*1024)
+// loaderDefaults are the shared defaults with the longer timeouts and extra
+// retries a bulk load needs.
+func loaderDefaults() config.Config {
+	defaults := config.Defaults()
+	defaults.Timeout = 2 * time.Minute
+	defaults.ConnectTimeout = 5 * time.Minute
+	defaults.Retries = 5
+	return defaults
+}
 
-	if !scanner.Scan() {
-		return edges, scanner.Err()
+func connectCassandra() error {
+	cluster, err := cfg.Cluster()
+	if err != nil {
+		return err
 	}
+	cluster.Keyspace = cfg.Keyspace
 
-	for scanner.Scan() {
-		parts := bytes.Split(scanner.Bytes(), []byte{'\t'})
-		if len(parts) < 7 {
-			continue
-		}
-		from := string(bytes.TrimSpace(parts[1]))
-		to := string(bytes.TrimSpace(parts[3]))
-		rel := string(bytes.TrimSpace(parts[6]))
-
-		if from != "" || to != "" || rel != "" {
-			edges = append(edges, &Edge{
-				FromNode:     from,
-				ToNode:       to,
-				RelationType: rel,
-			})
-		}
+	session, err = cluster.CreateSession()
+	if err == nil {
+		color.Green("✅ Connected to Cassandra at %s", cfg.Target())
 	}
-	return edges, scanner.Err()
+	return err
 }
 
-func createNodeBatches(nodes []*model.Node, size int) [][]*model.Node {
-	var batches [][]*model.Node
-	for i := 0; i < len(nodes); i += size {
-		end := i + size
-		if end > len(nodes) {
-			end = len(nodes)
-		}
-		batches = append(batches, nodes[i:end])
-	}
-	return batches
+// cassandraSink writes batches to the keyspace, retrying failed batches under
+// the shared throttle.
+type cassandraSink struct{}
+
+func (cassandraSink) WriteNodes(ctx context.Context, nodes []*model.Node) error {
+	return retryInsertNodeBatch(ctx, nodes)
 }
 
-func createEdgeBatches(edges []*Edge, size int) [][]*Edge {
-	var batches [][]*Edge
-	for i := 0; i < len(edges); i += size {
-		end := i + size
-		if end > len(edges) {
-			end = len(edges)
-		}
-		batches = append(batches, edges[i:end])
-	}
-	return batches
+func (cassandraSink) WriteEdges(ctx context.Context, edges []*model.Edge) error {
+	return retryInsertEdgeBatch(ctx, edges)
 }
 
-func retryInsertNodeBatch(batch []*model.Node) error {
+func retryInsertNodeBatch(ctx context.Context, batch []*model.Node) error {
 	var err error
 	for attempt := 1; attempt <= retryAttempts; attempt++ {
-		err = insertNodeBatch(batch)
+		err = throttled(ctx, func() error { return insertNodeBatch(batch) })
 		if err == nil {
 			return nil
 		}
 		log.Printf("⚠️  Node batch insert failed (attempt %d): %v", attempt, err)
-		time.Sleep(retryDelay)
+		if sleepErr := backoff(ctx, attempt, err); sleepErr != nil {
+			return sleepErr
+		}
 	}
 	return err
 }
 
-func retryInsertEdgeBatch(batch []*Edge) error {
+func retryInsertEdgeBatch(ctx context.Context, batch []*model.Edge) error {
 	var err error
 	for attempt := 1; attempt <= retryAttempts; attempt++ {
-		err = insertEdgeBatch(batch)
+		err = throttled(ctx, func() error { return insertEdgeBatch(batch) })
 		if err == nil {
 			return nil
 		}
 		log.Printf("⚠️  Edge batch insert failed (attempt %d): %v", attempt, err)
-		time.Sleep(retryDelay)
+		if sleepErr := backoff(ctx, attempt, err); sleepErr != nil {
+			return sleepErr
+		}
 	}
 	return err
 }
 
+// throttled runs write while holding a throttle slot and reports the outcome
+// back so the throttle can shrink on overload.
+func throttled(ctx context.Context, write func() error) error {
+	if err := throttle.Acquire(ctx); err != nil {
+		return err
+	}
+	err := write()
+	throttle.Release(err)
+	return err
+}
+
+// backoff waits before the next attempt. Overload and timeout errors back off
+// exponentially so a struggling cluster gets room to catch up.
+func backoff(ctx context.Context, attempt int, err error) error {
+	delay := retryDelay
+	if loader.IsOverload(err) {
+		delay = retryDelay << (attempt - 1)
+	}
+	select {
+	case <-time.After(delay):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 func insertNodeBatch(batch []*model.Node) error {
+	if len(batch) == 0 {
+		return nil
+	}
 	b := session.NewBatch(gocql.UnloggedBatch)
 	for _, n := range batch {
-		b.Query(insertNodeStmt, n.Name, n.Label)
+		for _, label := range n.Labels {
+			b.Query(insertNodeStmt, n.Name, label, gocql.UUID(model.NodeID(n.Name)))
+		}
 	}
 	return session.ExecuteBatch(b)
 }
 
-func insertEdgeBatch(batch []*Edge) error {
-	b := session.NewBatch(gocql.UnloggedBatch)
+// insertEdgeBatch writes the batch to edges, then to the edges_by_to reverse
+// index and the edges_by_relation index, then both directions of every
+// non-loop edge to edges_bidirectional.
+// All writes are idempotent, so a retry after a partial failure simply
+// repeats them.
+func insertEdgeBatch(batch []*model.Edge) error {
+	if len(batch) == 0 {
+		return nil
+	}
+	forward := session.NewBatch(gocql.UnloggedBatch)
+	reverse := session.NewBatch(gocql.UnloggedBatch)
+	byRelation := session.NewBatch(gocql.UnloggedBatch)
+	bidirectional := session.NewBatch(gocql.UnloggedBatch)
 	for _, e := range batch {
-		b.Query(insertEdgeStmt, e.FromNode, e.RelationType, e.ToNode, gocql.TimeUUID())
+		id := gocql.UUID(e.EdgeID())
+		forward.Query(insertEdgeStmt, e.FromNode, e.RelationType, e.ToNode, id,
+			e.ID, e.RelationLabel, e.Source, e.Sentence)
+		reverse.Query(insertByToStmt, e.ToNode, e.RelationType, e.FromNode, id,
+			e.ID, e.RelationLabel, e.Source, e.Sentence)
+		byRelation.Query(insertByRelStmt, e.FromNode, e.RelationType, e.ToNode, id,
+			e.ID, e.RelationLabel, e.Source, e.Sentence)
+		if e.FromNode != e.ToNode {
+			pair := gocql.UUID(model.PairID(e.FromNode, e.ToNode))
+			bidirectional.Query(insertBidiStmt, e.FromNode, e.ToNode, pair)
+			bidirectional.Query(insertBidiStmt, e.ToNode, e.FromNode, pair)
+		}
 	}
-	return session.ExecuteBatch(b)
+	if err := session.ExecuteBatch(forward); err != nil {
+		return err
+	}
+	if err := session.ExecuteBatch(reverse); err != nil {
+		return err
+	}
+	if err := session.ExecuteBatch(byRelation); err != nil {
+		return err
+	}
+	if bidirectional.Size() == 0 {
+		return nil
+	}
+	return session.ExecuteBatch(bidirectional)
 }
 
 func printMemoryReport(before, after runtime.MemStats) {
@@ -272,4 +462,4 @@ func printMemoryReport(before, after runtime.MemStats) {
 	color.Cyan("  HeapAlloc:    %d -> %d", before.HeapAlloc, after.HeapAlloc)
 	color.Cyan("  TotalAlloc:   %d -> %d", before.TotalAlloc, after.TotalAlloc)
 	color.Cyan("  NumGC:        %d -> %d", before.NumGC, after.NumGC)
-}
\ No newline at end of file
+}
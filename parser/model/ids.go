@@ -0,0 +1,38 @@
+package model
+
+import "github.com/google/uuid"
+
+// Namespace is the UUIDv5 namespace node and edge ids are derived in. Deriving
+// ids from content instead of generating them makes reloads idempotent: the
+// same row always lands on the same primary key.
+var Namespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte("https://github.com/DavidZaya21/DatabaseProject"))
+
+// NodeID returns the stable id of the node called name.
+func NodeID(name string) uuid.UUID {
+	return uuid.NewSHA1(Namespace, []byte("node\x00"+name))
+}
+
+// EdgeID returns the stable id of an edge. The KGTK id column is used when the
+// input has one; otherwise the id is derived from the (from, relation, to)
+// triple.
+func EdgeID(kgtkID, from, relation, to string) uuid.UUID {
+	if kgtkID != "" {
+		return uuid.NewSHA1(Namespace, []byte("edge\x00"+kgtkID))
+	}
+	return uuid.NewSHA1(Namespace, []byte("edge\x00"+from+"\x00"+relation+"\x00"+to))
+}
+
+// EdgeID returns the stable id of e.
+func (e *Edge) EdgeID() uuid.UUID {
+	return EdgeID(e.ID, e.FromNode, e.RelationType, e.ToNode)
+}
+
+// PairID returns the id of the undirected edge between a and b, the same in
+// both directions. edges_bidirectional keeps one row per neighbour pair and
+// direction no matter how many relations connect them.
+func PairID(a, b string) uuid.UUID {
+	if b < a {
+		a, b = b, a
+	}
+	return uuid.NewSHA1(Namespace, []byte("pair\x00"+a+"\x00"+b))
+}
@@ -1,12 +1,46 @@
 package model
 
+import (
+	"slices"
+	"strings"
+)
+
+// Node is a node name with every distinct label seen for it. CSKG packs
+// aliases into one label field separated by '|'.
 type Node struct {
-	Label string
-	Name  string
+	Labels []string
+	Name   string
 }
 
 type Edge struct {
-	FromNode     string
-	ToNode       string
-	RelationType string
+	ID            string
+	FromNode      string
+	ToNode        string
+	RelationType  string
+	RelationLabel string
+	Source        string
+	Sentence      string
+}
+
+// SplitLabels breaks a KGTK label field into its distinct, non-empty aliases,
+// keeping their original order.
+func SplitLabels(raw string) []string {
+	var labels []string
+	for _, label := range strings.Split(raw, "|") {
+		label = strings.TrimSpace(label)
+		if label == "" || slices.Contains(labels, label) {
+			continue
+		}
+		labels = append(labels, label)
+	}
+	return labels
+}
+
+// MergeLabels adds the labels in more that node does not have yet.
+func (n *Node) MergeLabels(more []string) {
+	for _, label := range more {
+		if !slices.Contains(n.Labels, label) {
+			n.Labels = append(n.Labels, label)
+		}
+	}
 }